@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+)
+
+// Instance lifecycle actions
+const (
+	InstanceActionStart  = "start"
+	InstanceActionStop   = "stop"
+	InstanceActionReboot = "reboot"
+)
+
+// InstanceActionResult reports what a lifecycle action did, or would do in a dry run
+type InstanceActionResult struct {
+	InstanceID    string `json:"instanceId"`
+	Action        string `json:"action"`
+	DryRun        bool   `json:"dryRun"`
+	PreviousState string `json:"previousState"`
+	CurrentState  string `json:"currentState"`
+	Message       string `json:"message"`
+}
+
+// ConfirmInstanceAction returns the token to pass to StartInstance, StopInstance
+// or RebootInstance. It is valid once, for that action on that instance only.
+func (a *App) ConfirmInstanceAction(instanceID string, action string) (string, error) {
+	switch action {
+	case InstanceActionStart, InstanceActionStop, InstanceActionReboot:
+	default:
+		return "", fmt.Errorf("invalid instance action %q: must be start, stop or reboot", action)
+	}
+	return a.issueConfirmation(action, instanceID)
+}
+
+// StartInstance starts a stopped instance. With dryRun only the permissions are checked.
+func (a *App) StartInstance(profile string, region string, instanceID string, token string, dryRun bool) (*InstanceActionResult, error) {
+	return a.runInstanceAction(profile, region, instanceID, InstanceActionStart, token, dryRun)
+}
+
+// StopInstance stops a running instance. With dryRun only the permissions are checked.
+func (a *App) StopInstance(profile string, region string, instanceID string, token string, dryRun bool) (*InstanceActionResult, error) {
+	return a.runInstanceAction(profile, region, instanceID, InstanceActionStop, token, dryRun)
+}
+
+// RebootInstance reboots a running instance. With dryRun only the permissions are checked.
+func (a *App) RebootInstance(profile string, region string, instanceID string, token string, dryRun bool) (*InstanceActionResult, error) {
+	return a.runInstanceAction(profile, region, instanceID, InstanceActionReboot, token, dryRun)
+}
+
+// runInstanceAction checks write access (except for dry runs, which change nothing)
+// and calls the EC2 API for the action in region, the Region of the scanned
+// instance ("" is the profile's region)
+func (a *App) runInstanceAction(profile, region, instanceID, action, token string, dryRun bool) (*InstanceActionResult, error) {
+	if !dryRun {
+		if err := a.checkWriteAccess(token, action, instanceID); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if region != "" {
+		cfg.Region = region
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+	result := &InstanceActionResult{InstanceID: instanceID, Action: action, DryRun: dryRun}
+
+	ids := []string{instanceID}
+	switch action {
+	case InstanceActionStart:
+		var out *ec2.StartInstancesOutput
+		out, err = ec2Client.StartInstances(a.ctx, &ec2.StartInstancesInput{InstanceIds: ids, DryRun: aws.Bool(dryRun)})
+		if err == nil && len(out.StartingInstances) > 0 {
+			result.PreviousState = instanceStateName(out.StartingInstances[0].PreviousState)
+			result.CurrentState = instanceStateName(out.StartingInstances[0].CurrentState)
+		}
+	case InstanceActionStop:
+		var out *ec2.StopInstancesOutput
+		out, err = ec2Client.StopInstances(a.ctx, &ec2.StopInstancesInput{InstanceIds: ids, DryRun: aws.Bool(dryRun)})
+		if err == nil && len(out.StoppingInstances) > 0 {
+			result.PreviousState = instanceStateName(out.StoppingInstances[0].PreviousState)
+			result.CurrentState = instanceStateName(out.StoppingInstances[0].CurrentState)
+		}
+	case InstanceActionReboot:
+		_, err = ec2Client.RebootInstances(a.ctx, &ec2.RebootInstancesInput{InstanceIds: ids, DryRun: aws.Bool(dryRun)})
+	}
+
+	if dryRun && isDryRunSuccess(err) {
+		result.Message = fmt.Sprintf("dry run: %s of %s would succeed", action, instanceID)
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s instance %s: %w", action, instanceID, err)
+	}
+	result.Message = fmt.Sprintf("%s of %s requested", action, instanceID)
+	return result, nil
+}
+
+// isDryRunSuccess reports whether err is the DryRunOperation error EC2 returns
+// when a dry run would have succeeded
+func isDryRunSuccess(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "DryRunOperation"
+}
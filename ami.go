@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ErrAMIDeregistered is returned when the AMI to compare no longer exists
+var ErrAMIDeregistered = errors.New("cannot compare, current AMI no longer exists")
+
+// AMI status reported for each instance
+const (
+	AMIStatusOK           = "OK"
+	AMIStatusDeprecated   = "deprecated"
+	AMIStatusDeregistered = "deregistered"
+)
+
+// AMIImage is the subset of an EC2 image we report on
+type AMIImage struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	OwnerID      string `json:"ownerId"`
+	CreationDate string `json:"creationDate"`
+}
+
+// AMIUpgrade tells whether a newer image exists for the AMI an instance runs
+type AMIUpgrade struct {
+	Current         AMIImage  `json:"current"`
+	Latest          *AMIImage `json:"latest"`
+	UpdateAvailable bool      `json:"updateAvailable"`
+}
+
+var amiDigitsPattern = regexp.MustCompile(`[0-9]+`)
+
+// amiNamePattern turns an AMI name into a DescribeImages name filter.
+// Dates and version numbers are replaced by wildcards, so
+// "amzn2-ami-hvm-2.0.20230404.0-x86_64-gp2" matches later builds of the same image family.
+func amiNamePattern(name string) string {
+	return amiDigitsPattern.ReplaceAllString(name, "*")
+}
+
+func toAMIImage(img ec2types.Image) AMIImage {
+	return AMIImage{
+		ID:           aws.ToString(img.ImageId),
+		Name:         aws.ToString(img.Name),
+		OwnerID:      aws.ToString(img.OwnerId),
+		CreationDate: aws.ToString(img.CreationDate),
+	}
+}
+
+// FindLatestAMI looks for a newer image from the same owner whose name matches the current AMI
+func (a *App) FindLatestAMI(profile string, currentAMIID string) (*AMIUpgrade, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	// 1. Describe the current AMI. A filter is used instead of ImageIds so that
+	// a deregistered image yields an empty result rather than an API error.
+	out, err := ec2Client.DescribeImages(a.ctx, &ec2.DescribeImagesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("image-id"), Values: []string{currentAMIID}},
+		},
+		IncludeDeprecated: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe image %s: %w", currentAMIID, err)
+	}
+	if len(out.Images) == 0 {
+		return nil, fmt.Errorf("%s: %w", currentAMIID, ErrAMIDeregistered)
+	}
+
+	current := toAMIImage(out.Images[0])
+	upgrade := &AMIUpgrade{Current: current}
+	if current.Name == "" || current.OwnerID == "" {
+		return nil, fmt.Errorf("image %s has no name or owner to compare against", currentAMIID)
+	}
+
+	// 2. List the images of the same owner and family
+	var candidates []ec2types.Image
+	pager := ec2.NewDescribeImagesPaginator(ec2Client, &ec2.DescribeImagesInput{
+		Owners: []string{current.OwnerID},
+		Filters: []ec2types.Filter{
+			{Name: aws.String("name"), Values: []string{amiNamePattern(current.Name)}},
+		},
+	})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(a.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe images for %s: %w", current.OwnerID, err)
+		}
+		candidates = append(candidates, page.Images...)
+	}
+
+	// 3. Newest first. CreationDate is ISO 8601, so it sorts lexically.
+	sort.Slice(candidates, func(i, j int) bool {
+		return aws.ToString(candidates[i].CreationDate) > aws.ToString(candidates[j].CreationDate)
+	})
+
+	if len(candidates) > 0 {
+		latest := toAMIImage(candidates[0])
+		upgrade.Latest = &latest
+		upgrade.UpdateAvailable = latest.ID != current.ID && latest.CreationDate > current.CreationDate
+	} else {
+		upgrade.Latest = &current
+	}
+	return upgrade, nil
+}
+
+// describeImagesBatchSize keeps the image-id filter within the API limits
+const describeImagesBatchSize = 200
+
+// describeAMIs describes the given AMIs in batches and returns them keyed by ID.
+// Deregistered images are simply missing from the map; deprecated and disabled ones are kept.
+func describeAMIs(ctx context.Context, client ec2.DescribeImagesAPIClient, ids []string) (map[string]ec2types.Image, error) {
+	images := make(map[string]ec2types.Image, len(ids))
+	for start := 0; start < len(ids); start += describeImagesBatchSize {
+		end := min(start+describeImagesBatchSize, len(ids))
+		pager := ec2.NewDescribeImagesPaginator(client, &ec2.DescribeImagesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("image-id"), Values: ids[start:end]},
+			},
+			IncludeDeprecated: aws.Bool(true),
+			IncludeDisabled:   aws.Bool(true),
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe images: %w", err)
+			}
+			for _, img := range page.Images {
+				images[aws.ToString(img.ImageId)] = img
+			}
+		}
+	}
+	return images, nil
+}
+
+// applyImageDetails copies the AMI metadata onto the instance running it
+func applyImageDetails(inst *EC2Instance, img ec2types.Image) {
+	inst.AMIName = aws.ToString(img.Name)
+	inst.AMIDescription = aws.ToString(img.Description)
+	inst.AMIOwner = aws.ToString(img.OwnerId)
+	if img.ImageOwnerAlias != nil {
+		// "amazon", "aws-marketplace"... read better than the account number
+		inst.AMIOwner = *img.ImageOwnerAlias
+	}
+	inst.AMICreationDate = aws.ToString(img.CreationDate)
+	inst.AMIAgeDays = amiAgeDays(img)
+	inst.AMIDeprecated = isDeprecated(img)
+	inst.AMIPublic = aws.ToBool(img.Public)
+	inst.AMIStatus = AMIStatusOK
+	if inst.AMIDeprecated {
+		inst.AMIStatus = AMIStatusDeprecated
+	}
+}
+
+// amiAgeDays returns how many whole days ago the image was created, 0 if unknown
+func amiAgeDays(img ec2types.Image) int {
+	if img.CreationDate == nil {
+		return 0
+	}
+	created, err := time.Parse(time.RFC3339, *img.CreationDate)
+	if err != nil {
+		return 0
+	}
+	return int(time.Since(created).Hours() / 24)
+}
+
+// isDeprecated reports whether the image deprecation time has passed
+func isDeprecated(img ec2types.Image) bool {
+	if img.DeprecationTime == nil {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, *img.DeprecationTime)
+	if err != nil {
+		return false
+	}
+	return !t.After(time.Now())
+}
@@ -2,56 +2,251 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/robfig/cron/v3"
+	bolt "go.etcd.io/bbolt"
+	"goCheckAmi/policy"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 	"gopkg.in/ini.v1"
 )
 
 // App struct
 type App struct {
 	ctx context.Context
+
+	mu               sync.Mutex
+	mfaTokenProvider func(MFAPrompt) (string, error)
+	mfaCodes         chan string
+	mfaPromptMu      sync.Mutex
+	retryMode        string
+	retryMaxAttempts int
+	retryMaxBackoff  time.Duration
+	callTimeout      time.Duration
+	callTimeouts     map[string]time.Duration
+	rateLimiter      *rate.Limiter
+	backend          Backend
+	demo             *DemoDataProvider
+	requests         map[string]context.CancelFunc
+	prices           *priceCache
+	writeMode        bool
+	confirmations    map[string]pendingConfirmation
+	awsFiles         awsFiles
+	configs          map[string]*cachedConfig
+	clients          map[clientKey]interface{}
+	store            *bolt.DB
+	history          *sql.DB
+	resultCacheTTL   time.Duration
+	cron             *cron.Cron
+	scheduled        map[string]cron.EntryID
+	watches          map[string]context.CancelFunc
+	policy           *policy.Policy
+	rego             *policy.Rego
+	notifiers        []notifier
+	trace            apiTrace
 }
 
 type EC2Instance struct {
-	Name string `json:"name"`
-	AMI  string `json:"ami"`
+	InstanceID       string `json:"instanceId"`
+	Name             string `json:"name"`
+	State            string `json:"state"`
+	InstanceType     string `json:"instanceType"`
+	AvailabilityZone string `json:"availabilityZone"`
+	VpcID            string `json:"vpcId"`
+	SubnetID         string `json:"subnetId"`
+	PrivateIP        string `json:"privateIp"`
+	PublicIP         string `json:"publicIp"`
+	// LaunchTime is RFC 3339, empty if unknown
+	LaunchTime string `json:"launchTime"`
+	// Platform is the platform details reported by EC2, e.g. "Linux/UNIX" or "Windows"
+	Platform string `json:"platform"`
+	// Lifecycle is on-demand, spot, scheduled or capacity-block
+	Lifecycle       string `json:"lifecycle"`
+	AMI             string `json:"ami"`
+	Region          string `json:"region"`
+	AccountID       string `json:"accountId"`
+	AMIName         string `json:"amiName"`
+	AMIDescription  string `json:"amiDescription"`
+	AMIOwner        string `json:"amiOwner"`
+	AMICreationDate string `json:"amiCreationDate"`
+	AMIAgeDays      int    `json:"amiAgeDays"`
+	AMIStale        bool   `json:"amiStale"`
+	AMIDeprecated   bool   `json:"amiDeprecated"`
+	// AMIStatus is OK, deprecated or deregistered; empty when the AMI could not be checked
+	AMIStatus string `json:"amiStatus"`
+	// AMIPublic is set when anyone can launch the AMI
+	AMIPublic bool `json:"amiPublic"`
+	// RecommendedAMI is the latest public AMI of the same family, when the AMI is an official one
+	RecommendedAMI string `json:"recommendedAmi"`
+	AMIDaysBehind  int    `json:"amiDaysBehind"`
+	// HourlyCostUSD and MonthlyCostUSD are the on-demand price, when costs were estimated
+	HourlyCostUSD  float64            `json:"hourlyCostUsd"`
+	MonthlyCostUSD float64            `json:"monthlyCostUsd"`
+	SecurityGroups []SecurityGroupRef `json:"securityGroups"`
+	// InstanceProfileARN is the attached IAM instance profile, empty if none
+	InstanceProfileARN string `json:"instanceProfileArn"`
+	// IAMRole and IAMFinding are filled by the IAM check: the role of the instance
+	// profile, and "no role" or "broad role: <policies>" when the role needs attention
+	IAMRole    string `json:"iamRole"`
+	IAMFinding string `json:"iamFinding"`
+	// IMDSHttpTokens is the MetadataOptions.HttpTokens setting: "required" (IMDSv2 only) or "optional"
+	IMDSHttpTokens string `json:"imdsHttpTokens"`
+	// IMDSv1Allowed is set when the metadata endpoint is enabled and doesn't require tokens
+	IMDSv1Allowed bool `json:"imdsV1Allowed"`
+	// Exposures lists the sensitive ports open to 0.0.0.0/0 or ::/0
+	Exposures []SecurityExposure `json:"exposures"`
+	// NonCompliant is set when a golden AMI list was given and the AMI is not on it
+	NonCompliant bool `json:"nonCompliant"`
+	// LoadBalancers are the load balancers the instance is registered behind, when scanned
+	LoadBalancers []string `json:"loadBalancers,omitempty"`
+	// StackName and StackLogicalID identify the CloudFormation stack owning the instance
+	StackName      string `json:"stackName"`
+	StackLogicalID string `json:"stackLogicalId"`
+	// Unmanaged is set by the stack mapping for instances no stack owns
+	Unmanaged bool `json:"unmanaged"`
+	// Pinned is set for the favorite instances, listed first
+	Pinned bool `json:"pinned,omitempty"`
+	// Tags are the tags of the instance
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 type AWSResult struct {
-	Parameters []string      `json:"parameters"`
-	Instances  []EC2Instance `json:"instances"`
+	Parameters []ParameterInfo `json:"parameters"`
+	Instances  []EC2Instance   `json:"instances"`
+	// Secrets holds the Secrets Manager secrets matching the filter, when requested
+	Secrets []SecretInfo `json:"secrets,omitempty"`
+	// LambdaFunctions lists the Lambda functions and their runtime status, when requested
+	LambdaFunctions []LambdaFunction `json:"lambdaFunctions,omitempty"`
+	// Services holds the resources of the services requested in ProcessingRequest.Services
+	Services map[string][]ServiceResource `json:"services,omitempty"`
+	// StaleAfterDays is the AMI age above which instances were marked stale
+	StaleAfterDays int `json:"staleAfterDays"`
+	// Lifecycles counts the instances per lifecycle (on-demand, spot...)
+	Lifecycles []LifecycleCount `json:"lifecycles"`
+	// EstimatedMonthlyCostUSD sums the instance costs, when requested
+	EstimatedMonthlyCostUSD float64 `json:"estimatedMonthlyCostUsd"`
+	// AmiStorageReport estimates the snapshot cost of the owned AMIs, when requested
+	AmiStorageReport *AmiStorageReport `json:"amiStorageReport,omitempty"`
+	// Checks holds the optional checks requested with ProcessingRequest.Checks
+	Checks *CheckResults `json:"checks,omitempty"`
+	// Compliance summarizes the golden AMI check, when one was requested
+	Compliance *ComplianceSummary `json:"compliance,omitempty"`
+	// Identity is the caller identity the scan ran as
+	Identity *CallerIdentity `json:"identity,omitempty"`
+	// AssumedRole is the role the scan ran as, empty when the identity isn't a role
+	AssumedRole string `json:"assumedRole,omitempty"`
+	// RoleChain lists the roles of the profile's source_profile chain, in assumption order
+	RoleChain []string `json:"roleChain,omitempty"`
+	// PolicyResults are the rules of the policy file matched by the instances and the
+	// violations of the Rego policies, when they are set
+	PolicyResults []policy.Result `json:"policyResults,omitempty"`
+	// Errors lists the parts of the scan that failed, the rest of the result being partial
+	Errors []ScanError `json:"errors,omitempty"`
+	// ScannedAt is when the scan ran (RFC 3339); Cached is set when it came from the result cache
+	ScannedAt string `json:"scannedAt"`
+	Cached    bool   `json:"cached,omitempty"`
+}
+
+// ScanError is a failure that didn't abort the scan: a whole region when Service
+// is empty, else the calls of one service in the region
+type ScanError struct {
+	Region string `json:"region"`
+	// Service is the AWS service whose calls failed, e.g. ssm or ec2
+	Service string `json:"service,omitempty"`
+	Error   string `json:"error"`
+}
+
+// addError records a failed part of the scan and logs it
+func (r *AWSResult) addError(region, service string, err error) {
+	slog.Warn("Partial scan", "region", region, "service", service, "err", err)
+	r.Errors = append(r.Errors, ScanError{Region: region, Service: service, Error: err.Error()})
 }
 
-// NewApp creates a new App application struct
+// NewApp creates a new App application struct calling AWS
 func NewApp() *App {
-	return &App{}
+	a := NewAppWithBackend(nil)
+	a.backend = sdkBackend{app: a}
+	return a
+}
+
+// NewAppWithBackend creates an App whose scans call backend instead of AWS,
+// e.g. mocks in tests
+func NewAppWithBackend(backend Backend) *App {
+	return &App{
+		backend:        backend,
+		mfaCodes:       make(chan string),
+		requests:       make(map[string]context.CancelFunc),
+		confirmations:  make(map[string]pendingConfirmation),
+		prices:         &priceCache{},
+		configs:        make(map[string]*cachedConfig),
+		clients:        make(map[clientKey]interface{}),
+		resultCacheTTL: defaultResultCacheTTL,
+		watches:        make(map[string]context.CancelFunc),
+		rateLimiter:    rate.NewLimiter(rate.Inf, 1),
+	}
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	// MFA codes are requested from the user through the frontend prompt
+	a.SetMFAPromptProvider(a.promptMFAToken)
+	// Custom shared config files from the settings
+	if prefs, err := a.LoadUserPrefs(); err == nil {
+		if err := setLogLevel(prefs.LogLevel); err != nil {
+			slog.Warn("Unable to set the log level", "err", err)
+		}
+		if err := a.setRetryPrefs(prefs); err != nil {
+			slog.Warn("Unable to set the retry settings", "err", err)
+		}
+		if err := a.setRateLimit(prefs.MaxRequestsPerSecond); err != nil {
+			slog.Warn("Unable to set the rate limit", "err", err)
+		}
+		a.setAWSFiles(prefs.AWSConfigFile, prefs.AWSCredentialsFile)
+		a.setResultCacheTTL(prefs.CacheTTLMinutes)
+		if err := a.setPolicyFile(prefs.PolicyFile); err != nil {
+			slog.Warn("Unable to load the policy file", "err", err)
+		}
+		if err := a.setRegoPolicy(prefs.RegoPolicyPath); err != nil {
+			slog.Warn("Unable to load the Rego policies", "err", err)
+		}
+		if err := a.setNotifiers(prefs); err != nil {
+			slog.Warn("Unable to set up the notifiers", "err", err)
+		}
+		a.setDemoMode(prefs.DemoMode)
+	}
+	a.startScheduler()
 }
 
-// getEndpointFromConfig tries to read 'endpoint_url' from ~/.aws/config for a profile
-func (a *App) getEndpointFromConfig(profile string) string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-	cfgPath := filepath.Join(home, ".aws", "config")
-	cfg, err := ini.Load(cfgPath)
+// shutdown is called when the app exits, releasing the local database
+func (a *App) shutdown(ctx context.Context) {
+	a.stopAllWatches()
+	a.stopScheduler()
+	a.closeStore()
+}
+
+// getProfileValue tries to read a key for a profile from the shared config file
+func (a *App) getProfileValue(profile string, key string) string {
+	cfg, err := a.loadAWSConfigFile()
 	if err != nil {
 		return ""
 	}
@@ -63,35 +258,39 @@ func (a *App) getEndpointFromConfig(profile string) string {
 	}
 
 	section := cfg.Section(sectionName)
-	if !section.HasKey("endpoint_url") {
+	if !section.HasKey(key) {
 		// Fallback: maybe user didn't use "profile " prefix for some reason or it's just "localstack"
 		// But AWS config standard is [profile name] except for default.
 		// Let's try just the name if headers didn't match
 		section = cfg.Section(profile)
 	}
 
-	if section.HasKey("endpoint_url") {
-		return section.Key("endpoint_url").String()
+	if section.HasKey(key) {
+		return section.Key(key).String()
 	}
 	return ""
 }
 
-// ListProfiles reads the AWS config file and returns a list of available profiles
-func (a *App) ListProfiles() ([]string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user home: %w", err)
-	}
+// getEndpointFromConfig tries to read 'endpoint_url' from the shared config file for a profile
+func (a *App) getEndpointFromConfig(profile string) string {
+	return a.getProfileValue(profile, "endpoint_url")
+}
 
-	configPath := filepath.Join(home, ".aws", "config")
+// ListProfiles reads the AWS config file and returns the available profiles with their metadata.
+// In demo mode the demo profile is the only one.
+func (a *App) ListProfiles() ([]ProfileInfo, error) {
+	if a.demoData() != nil {
+		return []ProfileInfo{{Name: demoProfile, Kind: ProfileKindUnknown, Region: demoRegions[0]}}, nil
+	}
+	configPath := a.awsConfigPath()
 	// If config doesn't exist, try credentials
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		configPath = filepath.Join(home, ".aws", "credentials")
+		configPath = a.awsCredentialsPath()
 	}
 
 	// If neither exist, return empty
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return []string{}, nil
+		return []ProfileInfo{}, nil
 	}
 
 	cfg, err := ini.Load(configPath)
@@ -99,42 +298,71 @@ func (a *App) ListProfiles() ([]string, error) {
 		return nil, fmt.Errorf("failed to load aws config: %w", err)
 	}
 
-	var profiles []string
+	// Access keys usually live in the credentials file
+	creds, err := ini.Load(a.awsCredentialsPath())
+	if err != nil {
+		creds = nil
+	}
+
+	var profiles []ProfileInfo
 	for _, section := range cfg.Sections() {
 		name := section.Name()
 		if name == "DEFAULT" {
 			continue // skip global default section from ini package if present
 		}
+		// [sso-session] and [services] blocks are referenced by profiles, they aren't profiles
+		if strings.HasPrefix(name, "sso-session ") || strings.HasPrefix(name, "services ") {
+			continue
+		}
 
 		// AWS config profiles are often named "profile name", except "default"
 		if strings.HasPrefix(name, "profile ") {
-			profiles = append(profiles, strings.TrimPrefix(name, "profile "))
+			profiles = append(profiles, a.profileInfo(strings.TrimPrefix(name, "profile "), section, creds))
 		} else {
 			// In credentials file or if it's just "default"
-			profiles = append(profiles, name)
+			profiles = append(profiles, a.profileInfo(name, section, creds))
 		}
 	}
 	return profiles, nil
 }
 
-// Processing handles the main logic: Auth, SSM, EC2
-func (a *App) Processing(profile string, filter string) (*AWSResult, error) {
+// loadConfig builds the SDK config for a profile, wiring the custom endpoint
+// (LocalStack support) and the MFA token provider when the profile needs one.
+// It returns the config together with the endpoint override, if any.
+func (a *App) loadConfig(ctx context.Context, profile string) (aws.Config, string, error) {
 	// 0. Check for custom endpoint (LocalStack support)
 	endpointURL := a.getEndpointFromConfig(profile)
 
 	loadOpts := []func(*config.LoadOptions) error{
 		config.WithSharedConfigProfile(profile),
+		a.retryLoadOption(),
+		a.traceLoadOption(),
+		a.timeoutLoadOption(),
+		a.rateLimitLoadOption(),
 	}
+	loadOpts = append(loadOpts, a.sharedFileLoadOptions()...)
 
-	if endpointURL != "" {
+	// Per-service endpoint_url overrides win over the profile-level one
+	overrides := a.serviceEndpoints(profile)
+	if endpointURL != "" || len(overrides) > 0 {
 		resolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			url := endpointURL
+			if override, ok := overrides[serviceConfigKey(service)]; ok {
+				url = override
+			}
+			if url == "" {
+				// Fall back to the default AWS endpoint
+				return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+			}
 			return aws.Endpoint{
-				URL:           endpointURL,
+				URL:           url,
 				SigningRegion: region, // Use region from config or default
 			}, nil
 		})
 		loadOpts = append(loadOpts, config.WithEndpointResolverWithOptions(resolver))
+	}
 
+	if endpointURL != "" {
 		// 0.1 Inject dummy credentials for LocalStack to prevent SDK from falling back to EC2 IMDS
 		// and failing with network errors (LocalStack accepts any non-empty creds).
 		loadOpts = append(loadOpts, config.WithCredentialsProvider(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
@@ -145,140 +373,439 @@ func (a *App) Processing(profile string, filter string) (*AWSResult, error) {
 				Source:          "HardcodedLocalStackCredentials",
 			}, nil
 		})))
+	} else if _, err := a.roleChain(profile); err != nil {
+		return aws.Config{}, "", err
+	} else if a.profileRequiresMFA(profile) {
+		// 0.2 Profiles with mfa_serial need a TOTP code when assuming the role.
+		// Without a provider the SDK would fail with an opaque error, so bail out early.
+		provider := a.getMFATokenProvider()
+		if provider == nil {
+			return aws.Config{}, "", fmt.Errorf("profile %q: %w", profile, ErrMFARequired)
+		}
+		prompt := MFAPrompt{Profile: profile, SerialNumber: a.mfaSerial(profile)}
+		loadOpts = append(loadOpts, config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
+			o.TokenProvider = func() (string, error) { return provider(prompt) }
+		}))
 	}
 
 	// 1. Load AWS Config
-	cfg, err := config.LoadDefaultConfig(a.ctx, loadOpts...)
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+		return aws.Config{}, "", fmt.Errorf("unable to load SDK config: %v", err)
+	}
+	return cfg, endpointURL, nil
+}
+
+// Processing handles the main logic: Auth, SSM, EC2
+func (a *App) Processing(profile string, filter string) (*AWSResult, error) {
+	return a.ProcessingWithFilter(profile, SSMFilter{NamePrefix: filter})
+}
+
+// ProcessingWithFilter is Processing with the full set of SSM filters
+func (a *App) ProcessingWithFilter(profile string, filter SSMFilter) (*AWSResult, error) {
+	return a.ProcessRequest(ProcessingRequest{Profile: profile, Filter: filter})
+}
+
+// authenticate loads the config for a profile and validates the identity,
+// falling back to an SSO login when the token is invalid or expired
+func (a *App) authenticate(ctx context.Context, profile string) (aws.Config, error) {
+	cfg, _, err := a.authenticateIdentity(ctx, profile)
+	return cfg, err
+}
+
+// authenticateIdentity is authenticate, also returning the caller identity.
+// Authenticated configs are cached per profile until their credentials expire.
+func (a *App) authenticateIdentity(ctx context.Context, profile string) (aws.Config, *sts.GetCallerIdentityOutput, error) {
+	if demo := a.demoData(); demo != nil {
+		identity, err := demo.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		return demo.config(), identity, err
+	}
+	if cached := a.cachedConfigFor(ctx, profile); cached != nil {
+		return cached.cfg, cached.identity, nil
+	}
+
+	cfg, endpointURL, err := a.loadConfig(ctx, profile)
+	if err != nil {
+		return aws.Config{}, nil, err
+	}
+
+	// 1.1 Make sure a custom endpoint answers, so a wrong port isn't reported as an identity error
+	if endpointURL != "" {
+		if err := a.probeEndpoint(ctx, endpointURL); err != nil {
+			return aws.Config{}, nil, err
+		}
 	}
 
 	// 2. Validate Auth (check identity)
-	stsClient := sts.NewFromConfig(cfg)
-	_, err = stsClient.GetCallerIdentity(a.ctx, &sts.GetCallerIdentityInput{})
+	identity, err := a.clientBackend().STS(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		// If we are using a custom endpoint (e.g. LocalStack), do not attempt SSO login.
 		// Use the error from STS as the source of truth.
 		if endpointURL != "" {
-			return nil, fmt.Errorf("failed to validate identity with custom endpoint %q: %w. Ensure LocalStack is running and credentials are configured", endpointURL, err)
+			return aws.Config{}, nil, fmt.Errorf("endpoint %q reachable but credentials/identity invalid: %w. Ensure credentials are configured", endpointURL, err)
 		}
 
-		log.Printf("Token invalid or expired. Attempting SSO login for profile: %s", profile)
+		// MFA profiles are not SSO backed; a rejected or missing code won't be fixed by a login.
+		if a.profileRequiresMFA(profile) {
+			return aws.Config{}, nil, fmt.Errorf("failed to validate identity with MFA for profile %q: %w", profile, err)
+		}
 
-		// Check if 'aws' is in PATH before trying to run it
-		if _, pathErr := exec.LookPath("aws"); pathErr != nil {
-			return nil, fmt.Errorf("aws cli not found in PATH, cannot perform sso login: %w", err)
+		ssoProfile := a.ssoRootProfile(profile)
+		settings, ok := a.profileSSOSettings(ssoProfile)
+		if !ok {
+			return aws.Config{}, nil, fmt.Errorf("failed to validate identity for profile %q: %w", profile, err)
 		}
 
-		// Run aws sso login
-		cmd := exec.Command("aws", "sso", "login", "--profile", profile)
-		// cmd.Stdout = os.Stdout // If we want to see output in console
-		// cmd.Stderr = os.Stderr
-		// This might open a browser window and wait.
-		if runErr := cmd.Run(); runErr != nil {
-			return nil, fmt.Errorf("aws sso login failed: %w", runErr)
+		slog.Info("Token invalid or expired, attempting SSO login", "profile", ssoProfile)
+		if loginErr := a.ssoLogin(ctx, ssoProfile, settings); loginErr != nil {
+			return aws.Config{}, nil, fmt.Errorf("sso login failed: %w", loginErr)
 		}
 
 		// Reload config after login
-		cfg, err = config.LoadDefaultConfig(a.ctx, loadOpts...)
+		cfg, _, err = a.loadConfig(ctx, profile)
+		if err != nil {
+			return aws.Config{}, nil, fmt.Errorf("unable to reload SDK config after login: %v", err)
+		}
+		identity, err = a.clientBackend().STS(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 		if err != nil {
-			return nil, fmt.Errorf("unable to reload SDK config after login: %v", err)
+			return aws.Config{}, nil, fmt.Errorf("failed to validate identity after login: %w", err)
 		}
 	}
+	a.storeConfig(profile, cfg, identity)
+	return cfg, identity, nil
+}
+
+// scanRegion lists the SSM parameters and EC2 instances in the region of cfg.
+// Both scans run concurrently. When one of them or an optional part fails, the
+// result is partial and lists the failure in Errors; only both failing is an error.
+func (a *App) scanRegion(ctx context.Context, cfg aws.Config, req ProcessingRequest) (*AWSResult, error) {
+	filters, err := req.Filter.parameterFilters()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateServices(req.Services); err != nil {
+		return nil, err
+	}
 
-	result := &AWSResult{}
+	result := &AWSResult{StaleAfterDays: req.staleAfterDays()}
+	var ssmErr, ec2Err, secretsErr error
+	var g errgroup.Group
 
 	// 3. SSM Parameters
-	ssmClient := ssm.NewFromConfig(cfg)
-	// Strategy: If filter ends with *, treat as path? Or just describe params?
-	// User said: "considere um wildcard no fim do filtro mas nao no inicio" -> prefix match.
-	// If it looks like a path (starts with /), use path?
-	// Actually DescribeParameters filters are limited. "Name" filter supports "BeginsWith".
-	// Let's use DescribeParameters with filter "Name" BeginsWith input (trimmed of *)
-
-	cleanFilter := strings.TrimSuffix(filter, "*")
-	// If filter is empty, maybe fetch all? Let's assume user wants to filter something.
-
-	var params []string
-	// Note: Verify if "BeginsWith" is default or explicit?
-	// The AWS SDK 'ParametersFilter' behavior depends on usage.
-	// For DescribeParameters, 'Name' filter automatically does exact match.
-	// Wait, DescribeParameters supports "Name" with "BeginsWith" ONLY for GetParametersByPath?
-	// No, DescribeParameters has 'Filters' (Key, Values). Keys: Name, Type, KeyId.
-	// Documentation says: "ParametersFilterKeyName ... The name of the parameter. ... The results include parameters that match the specified name. If you use the wildcard character (*), the results include parameters that match the specified name pattern."
-	// So if user passed "foo*", we can just pass that directly?
-	// User said "wildcard at end but not start".
-	// I will just pass the filter as is if it has *, or append * if logic requires.
-	// But user said "considere um wildcard no fim do filtro mas nao no inicio".
-	// It means if user types "prod", I should search for "prod*".
-	// If I pass "prod*" to filter values, it should work.
-
-	searchFilter := cleanFilter
-	if !strings.HasSuffix(searchFilter, "*") {
-		// Just to be safe, AWS might need explicit * for Contains/BeginsWith behavior in DescribeParameters?
-		// Actually for DescribeParameters: "Allowed values: Name, Type, KeyId".
-		// And for values: "You can use the wildcard character (*)."
-		// So if user input is "foo", and I want "foo*", I should append *.
-		// But if user input `foo*` already, I leave it.
-	}
-	// Let's ensure there is one * at end.
-	if !strings.HasSuffix(searchFilter, "*") {
-		searchFilter = searchFilter + "*"
+	g.Go(func() error {
+		result.Parameters, ssmErr = a.listFilteredParameters(ctx, a.clientBackend().SSM(cfg), cfg.Region, req.Filter, filters)
+		return nil
+	})
+
+	// 4. EC2 Instances
+	g.Go(func() error {
+		result.Instances, ec2Err = a.listInstances(ctx, a.clientBackend().EC2(cfg), cfg.Region, req)
+		return nil
+	})
+
+	// Optional: Secrets Manager, alongside the parameters
+	if req.Secrets {
+		g.Go(func() error {
+			result.Secrets, secretsErr = a.listSecrets(ctx, a.clientBackend().SecretsManager(cfg), cfg.Region, req.Filter)
+			return nil
+		})
 	}
 
+	_ = g.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ssmErr != nil && ec2Err != nil {
+		return nil, joinScanErrors(ssmErr, ec2Err)
+	}
+	if ssmErr != nil {
+		result.addError(cfg.Region, "ssm", ssmErr)
+	}
+	if ec2Err != nil {
+		result.addError(cfg.Region, "ec2", ec2Err)
+	}
+	if secretsErr != nil {
+		result.addError(cfg.Region, "secretsmanager", secretsErr)
+	}
+	result.Lifecycles = lifecycleBreakdown(result.Instances)
+
+	// 5. Optional: compare against the latest public AMIs
+	if req.CompareLatest {
+		if err := a.compareLatestPublicAMIs(ctx, cfg, result.Instances); err != nil {
+			result.addError(cfg.Region, "ssm", fmt.Errorf("failed to compare with the latest public AMIs: %w", err))
+		}
+	}
+
+	// 6. Optional: snapshot storage of the AMIs owned by the account
+	if req.StorageReport {
+		result.AmiStorageReport, err = amiStorageReport(ctx, a.clientBackend().EC2(cfg), cfg.Region)
+		if err != nil {
+			result.addError(cfg.Region, "ec2", fmt.Errorf("failed to build the AMI storage report: %w", err))
+		}
+	}
+
+	// 7. Optional: on-demand cost of the instances
+	if req.EstimateCost {
+		result.EstimatedMonthlyCostUSD, err = a.estimateCosts(ctx, cfg, result.Instances)
+		if err != nil {
+			result.addError(cfg.Region, "pricing", fmt.Errorf("failed to estimate the instance costs: %w", err))
+		}
+	}
+
+	// 8. Optional checks
+	if req.Checks.any() {
+		result.Checks = a.runChecks(ctx, cfg, result, req.Checks)
+	}
+
+	// 9. Optional: Lambda functions and their runtimes
+	if req.Lambda {
+		result.LambdaFunctions, err = listLambdaFunctions(ctx, lambda.NewFromConfig(cfg), cfg.Region)
+		if err != nil {
+			result.addError(cfg.Region, "lambda", fmt.Errorf("failed to list the Lambda functions: %w", err))
+		}
+	}
+
+	// 10. Optional: CloudFormation stacks owning the instances
+	if req.StackMapping {
+		if err := mapInstanceStacks(ctx, cloudformation.NewFromConfig(cfg), result.Instances); err != nil {
+			result.addError(cfg.Region, "cloudformation", fmt.Errorf("failed to map the instances to stacks: %w", err))
+		}
+	}
+
+	// 11. Optional: other services (RDS...)
+	if len(req.Services) > 0 {
+		result.Services = a.scanServices(ctx, cfg, req)
+		if targetGroups, ok := result.Services[elbService]; ok {
+			attachLoadBalancers(result.Instances, targetGroups)
+		}
+	}
+	return result, nil
+}
+
+// joinScanErrors merges the errors of concurrent scans, dropping the
+// cancellations that were only caused by the other scan failing first
+func joinScanErrors(errs ...error) error {
+	var failures []error
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) == 0 {
+		// Only cancellations: the caller's context was cancelled
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// listFilteredParameters returns the parameters matching filter,
+// using filters built by filter.parameterFilters
+func (a *App) listFilteredParameters(ctx context.Context, ssmClient SSMAPI, region string, filter SSMFilter, filters []ssmtypes.ParameterStringFilter) ([]ParameterInfo, error) {
+	if filter.usePathMode() {
+		return a.listParametersByPath(ctx, ssmClient, region, filter.patterns()[0], filters)
+	}
+	match, err := filter.nameMatcher()
+	if err != nil {
+		return nil, err
+	}
+	params, err := a.listParameters(ctx, ssmClient, region, filters)
+	if err != nil || match == nil {
+		return params, err
+	}
+
+	kept := params[:0]
+	for _, p := range params {
+		if match(p.Name) {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
+
+// listParameters returns the parameters of the region matching filters
+func (a *App) listParameters(ctx context.Context, ssmClient ssm.DescribeParametersAPIClient, region string, filters []ssmtypes.ParameterStringFilter) ([]ParameterInfo, error) {
+	var params []ParameterInfo
+	pages := 0
 	paginator := ssm.NewDescribeParametersPaginator(ssmClient, &ssm.DescribeParametersInput{
-		Filters: []ssmtypes.ParametersFilter{
-			{
-				Key:    ssmtypes.ParametersFilterKeyName,
-				Values: []string{searchFilter},
-			},
-		},
+		ParameterFilters: filters,
 	})
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(a.ctx)
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list params: %w", err)
 		}
 		for _, p := range page.Parameters {
 			if p.Name != nil {
-				params = append(params, *p.Name)
+				params = append(params, parameterInfoFromMetadata(p, region))
 			}
 		}
+		pages++
+		a.reportProgress(ctx, ScanProgress{Region: region, Phase: "ssm", Pages: pages, Items: len(params)})
 	}
-	result.Parameters = params
+	return params, nil
+}
 
-	// 4. EC2 Instances
-	ec2Client := ec2.NewFromConfig(cfg)
-	ec2Pager := ec2.NewDescribeInstancesPaginator(ec2Client, &ec2.DescribeInstancesInput{})
+// listParametersByPath returns the parameters under a "/" prefix,
+// listing the hierarchy recursively with GetParametersByPath
+func (a *App) listParametersByPath(ctx context.Context, ssmClient ssm.GetParametersByPathAPIClient, region, prefix string, filters []ssmtypes.ParameterStringFilter) ([]ParameterInfo, error) {
+	path, namePrefix := parameterPath(prefix)
+
+	var params []ParameterInfo
+	pages := 0
+	paginator := ssm.NewGetParametersByPathPaginator(ssmClient, &ssm.GetParametersByPathInput{
+		Path:             aws.String(path),
+		Recursive:        aws.Bool(true),
+		ParameterFilters: filters,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get params by path %s: %w", path, err)
+		}
+		for _, p := range page.Parameters {
+			if p.Name != nil && strings.HasPrefix(*p.Name, namePrefix) {
+				params = append(params, parameterInfoFromParameter(p, region))
+			}
+		}
+		pages++
+		a.reportProgress(ctx, ScanProgress{Region: region, Phase: "ssm", Pages: pages, Items: len(params)})
+	}
+	return params, nil
+}
+
+// toEC2Instance converts an instance returned by DescribeInstances
+func toEC2Instance(inst ec2types.Instance, region string, accountID string) EC2Instance {
+	var name, stack, logicalID string
+	tags := make(map[string]string, len(inst.Tags))
+	for _, tag := range inst.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		switch aws.ToString(tag.Key) {
+		case "Name":
+			name = aws.ToString(tag.Value)
+		case stackNameTag:
+			stack = aws.ToString(tag.Value)
+		case stackLogicalIDTag:
+			logicalID = aws.ToString(tag.Value)
+		}
+	}
+	ami := ""
+	if inst.ImageId != nil {
+		ami = *inst.ImageId
+	}
+	var launchTime string
+	if inst.LaunchTime != nil {
+		launchTime = inst.LaunchTime.UTC().Format(time.RFC3339)
+	}
+	var az string
+	if inst.Placement != nil {
+		az = aws.ToString(inst.Placement.AvailabilityZone)
+	}
+	var httpTokens string
+	imdsV1 := false
+	if inst.MetadataOptions != nil {
+		httpTokens = string(inst.MetadataOptions.HttpTokens)
+		imdsV1 = inst.MetadataOptions.HttpTokens == ec2types.HttpTokensStateOptional &&
+			inst.MetadataOptions.HttpEndpoint != ec2types.InstanceMetadataEndpointStateDisabled
+	}
+	var profileARN string
+	if inst.IamInstanceProfile != nil {
+		profileARN = aws.ToString(inst.IamInstanceProfile.Arn)
+	}
+	groups := []SecurityGroupRef{}
+	for _, g := range inst.SecurityGroups {
+		groups = append(groups, SecurityGroupRef{ID: aws.ToString(g.GroupId), Name: aws.ToString(g.GroupName)})
+	}
+	return EC2Instance{
+		InstanceID:         aws.ToString(inst.InstanceId),
+		Name:               name,
+		State:              instanceStateName(inst.State),
+		InstanceType:       string(inst.InstanceType),
+		AvailabilityZone:   az,
+		VpcID:              aws.ToString(inst.VpcId),
+		SubnetID:           aws.ToString(inst.SubnetId),
+		PrivateIP:          aws.ToString(inst.PrivateIpAddress),
+		PublicIP:           aws.ToString(inst.PublicIpAddress),
+		LaunchTime:         launchTime,
+		Platform:           aws.ToString(inst.PlatformDetails),
+		Lifecycle:          instanceLifecycle(inst),
+		AMI:                ami,
+		Region:             region,
+		AccountID:          accountID,
+		SecurityGroups:     groups,
+		IMDSHttpTokens:     httpTokens,
+		InstanceProfileARN: profileARN,
+		IMDSv1Allowed:      imdsV1,
+		StackName:          stack,
+		StackLogicalID:     logicalID,
+		Tags:               tags,
+	}
+}
+
+// instanceStateName returns the name of an instance state, "" if unknown
+func instanceStateName(s *ec2types.InstanceState) string {
+	if s == nil {
+		return ""
+	}
+	return string(s.Name)
+}
+
+// listInstances returns the EC2 instances of the region along with their AMI details
+func (a *App) listInstances(ctx context.Context, ec2Client EC2API, region string, req ProcessingRequest) ([]EC2Instance, error) {
+	filters, err := buildInstanceFilters(req.InstanceFilter)
+	if err != nil {
+		return nil, err
+	}
+	ec2Pager := ec2.NewDescribeInstancesPaginator(ec2Client, &ec2.DescribeInstancesInput{
+		Filters: filters,
+	})
 
 	var instances []EC2Instance
+	pages := 0
 	for ec2Pager.HasMorePages() {
-		page, err := ec2Pager.NextPage(a.ctx)
+		page, err := ec2Pager.NextPage(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to describe instances: %w", err)
 		}
 		for _, res := range page.Reservations {
 			for _, inst := range res.Instances {
-				var name string
-				for _, tag := range inst.Tags {
-					if *tag.Key == "Name" {
-						name = *tag.Value
-						break
-					}
-				}
-				ami := ""
-				if inst.ImageId != nil {
-					ami = *inst.ImageId
-				}
-				instances = append(instances, EC2Instance{
-					Name: name,
-					AMI:  ami,
-				})
+				instances = append(instances, toEC2Instance(inst, region, aws.ToString(res.OwnerId)))
 			}
 		}
+		pages++
+		a.reportProgress(ctx, ScanProgress{Region: region, Phase: "ec2", Pages: pages, Items: len(instances)})
 	}
-	result.Instances = instances
 
-	return result, nil
+	// Resolve the AMI details, one DescribeImages batch for all distinct AMIs
+	amiIDs := distinctAMIs(instances)
+	if len(amiIDs) > 0 {
+		a.reportProgress(ctx, ScanProgress{Region: region, Phase: "ami", Items: len(amiIDs)})
+		images, err := describeAMIs(ctx, ec2Client, amiIDs)
+		if err != nil {
+			// Not fatal: the inventory is still useful without the AMI details
+			slog.Warn("Unable to describe AMIs", "err", err)
+		}
+		for i := range instances {
+			img, ok := images[instances[i].AMI]
+			switch {
+			case ok:
+				applyImageDetails(&instances[i], img)
+				instances[i].AMIStale = instances[i].AMIAgeDays > req.staleAfterDays()
+			case err == nil && instances[i].AMI != "":
+				// DescribeImages worked but doesn't know the image anymore
+				instances[i].AMIStatus = AMIStatusDeregistered
+			}
+		}
+	}
+
+	// Flag the sensitive ports the security groups open to the internet
+	if err := auditSecurityGroups(ctx, ec2Client, instances); err != nil {
+		slog.Warn("Unable to audit security groups", "err", err)
+	}
+	return instances, nil
 }
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// AMIRegionAvailability tells whether an AMI (or a copy of it) exists in a region
+type AMIRegionAvailability struct {
+	Region    string `json:"region"`
+	Available bool   `json:"available"`
+	// ImageID is the ID of the matching image in that region; copies get a new ID
+	ImageID string `json:"imageId"`
+	Error   string `json:"error,omitempty"`
+}
+
+// AMIAvailabilityRow is one line of the AMI × region matrix
+type AMIAvailabilityRow struct {
+	AMI            string                  `json:"ami"`
+	Name           string                  `json:"name"`
+	OwnerID        string                  `json:"ownerId"`
+	Regions        []AMIRegionAvailability `json:"regions"`
+	MissingRegions []string                `json:"missingRegions"`
+}
+
+// AMIAvailabilityMatrix is the result of CheckAMIAvailability
+type AMIAvailabilityMatrix struct {
+	SourceRegion string               `json:"sourceRegion"`
+	Regions      []string             `json:"regions"`
+	AMIs         []AMIAvailabilityRow `json:"amis"`
+}
+
+// CheckAMIAvailability checks whether the given AMIs exist in each target region,
+// e.g. before a DR exercise. Without AMIs, the ones used by the instances of the
+// profile's region are checked. Since AMI IDs are regional, an image in another
+// region matches when it has the same owner and name (which is what CopyImage keeps).
+func (a *App) CheckAMIAvailability(profile string, amis []string, regions []string) (*AMIAvailabilityMatrix, error) {
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no target regions")
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	if len(amis) == 0 {
+		instances, err := a.listInstances(a.ctx, ec2Client, cfg.Region, ProcessingRequest{})
+		if err != nil {
+			return nil, err
+		}
+		amis = distinctAMIs(instances)
+	}
+	regions, err = a.resolveRegions(a.ctx, cfg, regions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Names and owners of the source AMIs
+	source, err := describeAMIs(a.ctx, ec2Client, amis)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &AMIAvailabilityMatrix{SourceRegion: cfg.Region, Regions: regions}
+	rows := make([]AMIAvailabilityRow, len(amis))
+	for i, id := range amis {
+		rows[i] = AMIAvailabilityRow{
+			AMI:            id,
+			Name:           aws.ToString(source[id].Name),
+			OwnerID:        aws.ToString(source[id].OwnerId),
+			Regions:        make([]AMIRegionAvailability, len(regions)),
+			MissingRegions: []string{},
+		}
+	}
+
+	// 2. Look them up in every target region
+	var mu sync.Mutex
+	g := new(errgroup.Group)
+	g.SetLimit(maxRegionWorkers)
+	for r, region := range regions {
+		g.Go(func() error {
+			regionCfg := cfg.Copy()
+			regionCfg.Region = region
+			found, err := findAMICopies(a.ctx, ec2.NewFromConfig(regionCfg), amis, source)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i, id := range amis {
+				cell := AMIRegionAvailability{Region: region}
+				if err != nil {
+					cell.Error = err.Error()
+				} else if copyID, ok := found[id]; ok {
+					cell.Available = true
+					cell.ImageID = copyID
+				} else {
+					rows[i].MissingRegions = append(rows[i].MissingRegions, region)
+				}
+				rows[i].Regions[r] = cell
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	matrix.AMIs = rows
+	return matrix, nil
+}
+
+// findAMICopies maps each source AMI ID to the matching image in the region of client
+func findAMICopies(ctx context.Context, client *ec2.Client, amis []string, source map[string]ec2types.Image) (map[string]string, error) {
+	found := make(map[string]string)
+
+	// Same ID: only possible in the source region, but cheap to check
+	byID, err := describeAMIs(ctx, client, amis)
+	if err != nil {
+		return nil, err
+	}
+	for id := range byID {
+		found[id] = id
+	}
+
+	// Same owner and name: copies made with CopyImage
+	namesByOwner := make(map[string][]string)
+	for _, id := range amis {
+		img, ok := source[id]
+		if _, done := found[id]; done || !ok || img.Name == nil || img.OwnerId == nil {
+			continue
+		}
+		namesByOwner[*img.OwnerId] = append(namesByOwner[*img.OwnerId], *img.Name)
+	}
+	for owner, names := range namesByOwner {
+		byName := make(map[string]string)
+		for start := 0; start < len(names); start += describeImagesBatchSize {
+			end := min(start+describeImagesBatchSize, len(names))
+			pager := ec2.NewDescribeImagesPaginator(client, &ec2.DescribeImagesInput{
+				Owners:            []string{owner},
+				Filters:           []ec2types.Filter{{Name: aws.String("name"), Values: names[start:end]}},
+				IncludeDeprecated: aws.Bool(true),
+			})
+			for pager.HasMorePages() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to describe images of %s: %w", owner, err)
+				}
+				for _, img := range page.Images {
+					byName[aws.ToString(img.Name)] = aws.ToString(img.ImageId)
+				}
+			}
+		}
+		for _, id := range amis {
+			img := source[id]
+			if aws.ToString(img.OwnerId) != owner {
+				continue
+			}
+			if copyID, ok := byName[aws.ToString(img.Name)]; ok {
+				if _, done := found[id]; !done {
+					found[id] = copyID
+				}
+			}
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"gopkg.in/ini.v1"
+)
+
+// awsFiles are the shared config and credentials files chosen in the app settings;
+// empty paths fall back to AWS_CONFIG_FILE / AWS_SHARED_CREDENTIALS_FILE, then ~/.aws
+type awsFiles struct {
+	config      string
+	credentials string
+}
+
+// setAWSFiles switches the shared config files and drops the configs loaded from the old ones
+func (a *App) setAWSFiles(configFile, credentialsFile string) {
+	a.mu.Lock()
+	changed := a.awsFiles.config != configFile || a.awsFiles.credentials != credentialsFile
+	a.awsFiles = awsFiles{config: configFile, credentials: credentialsFile}
+	a.mu.Unlock()
+	if changed {
+		a.invalidateAllProfiles()
+	}
+}
+
+// awsConfigPath returns the shared config file: app setting, AWS_CONFIG_FILE, then ~/.aws/config
+func (a *App) awsConfigPath() string {
+	a.mu.Lock()
+	path := a.awsFiles.config
+	a.mu.Unlock()
+	return sharedFilePath(path, "AWS_CONFIG_FILE", "config")
+}
+
+// awsCredentialsPath returns the shared credentials file: app setting,
+// AWS_SHARED_CREDENTIALS_FILE, then ~/.aws/credentials
+func (a *App) awsCredentialsPath() string {
+	a.mu.Lock()
+	path := a.awsFiles.credentials
+	a.mu.Unlock()
+	return sharedFilePath(path, "AWS_SHARED_CREDENTIALS_FILE", "credentials")
+}
+
+// sharedFilePath resolves a shared file from the setting, the environment variable or ~/.aws
+func sharedFilePath(setting, envVar, name string) string {
+	if setting != "" {
+		return expandHome(setting)
+	}
+	if env := os.Getenv(envVar); env != "" {
+		return expandHome(env)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".aws", name)
+}
+
+// expandHome expands a leading ~/ like the AWS CLI does
+func expandHome(path string) string {
+	if len(path) < 2 || path[:2] != "~/" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+// sharedFileLoadOptions points the SDK at the shared files of the app settings.
+// The SDK honors the environment variables by itself.
+func (a *App) sharedFileLoadOptions() []func(*config.LoadOptions) error {
+	a.mu.Lock()
+	files := a.awsFiles
+	a.mu.Unlock()
+
+	var opts []func(*config.LoadOptions) error
+	if files.config != "" {
+		opts = append(opts, config.WithSharedConfigFiles([]string{expandHome(files.config)}))
+	}
+	if files.credentials != "" {
+		opts = append(opts, config.WithSharedCredentialsFiles([]string{expandHome(files.credentials)}))
+	}
+	return opts
+}
+
+// loadAWSConfigFile parses the shared config file. Indented lines continue the
+// previous value, as for the nested settings of [services] sections.
+func (a *App) loadAWSConfigFile() (*ini.File, error) {
+	return ini.LoadSources(ini.LoadOptions{AllowPythonMultilineValues: true}, a.awsConfigPath())
+}
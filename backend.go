@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// SSMAPI is the part of the SSM API the scans call
+type SSMAPI interface {
+	ssm.DescribeParametersAPIClient
+	ssm.GetParametersByPathAPIClient
+}
+
+// EC2API is the part of the EC2 API the scans call
+type EC2API interface {
+	ec2.DescribeInstancesAPIClient
+	ec2.DescribeImagesAPIClient
+	ec2.DescribeSecurityGroupsAPIClient
+	ec2.DescribeSnapshotsAPIClient
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+}
+
+// SecretsManagerAPI is the part of the Secrets Manager API the scans call
+type SecretsManagerAPI interface {
+	secretsmanager.ListSecretsAPIClient
+}
+
+// STSAPI is the part of the STS API used to validate the credentials
+type STSAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// Backend creates the clients the scans call, for the credentials and region of a config
+type Backend interface {
+	SSM(cfg aws.Config) SSMAPI
+	EC2(cfg aws.Config) EC2API
+	SecretsManager(cfg aws.Config) SecretsManagerAPI
+	STS(cfg aws.Config) STSAPI
+}
+
+// sdkBackend calls AWS with the SDK clients, reusing them per credentials and region
+type sdkBackend struct {
+	app *App
+}
+
+func (b sdkBackend) SSM(cfg aws.Config) SSMAPI {
+	return cachedClient(b.app, cfg, "ssm", ssm.NewFromConfig)
+}
+
+func (b sdkBackend) EC2(cfg aws.Config) EC2API {
+	return cachedClient(b.app, cfg, "ec2", ec2.NewFromConfig)
+}
+
+func (b sdkBackend) SecretsManager(cfg aws.Config) SecretsManagerAPI {
+	return cachedClient(b.app, cfg, "secretsmanager", secretsmanager.NewFromConfig)
+}
+
+func (b sdkBackend) STS(cfg aws.Config) STSAPI {
+	return sts.NewFromConfig(cfg)
+}
+
+// clientBackend returns the backend of the scans: the demo account in demo mode
+func (a *App) clientBackend() Backend {
+	if demo := a.demoData(); demo != nil {
+		return *demo
+	}
+	return a.backend
+}
+
+// demoData returns the demo account, nil when demo mode is off
+func (a *App) demoData() *DemoDataProvider {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.demo
+}
+
+// setDemoMode switches the scans to the demo account, which needs no AWS
+// credentials, or back to AWS
+func (a *App) setDemoMode(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case enabled && a.demo == nil:
+		demo := NewDemoDataProvider()
+		a.demo = &demo
+	case !enabled:
+		a.demo = nil
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// CheckOptions selects the optional checks of a scan. They cost extra API calls,
+// so they are off by default to keep the scan fast.
+type CheckOptions struct {
+	// Volumes lists the EBS volumes of each instance and flags the unencrypted ones
+	Volumes bool `json:"volumes"`
+	// IAM resolves the role of each instance and flags missing or overly broad roles
+	IAM bool `json:"iam"`
+}
+
+// any reports whether at least one check is requested
+func (o CheckOptions) any() bool {
+	return o.Volumes || o.IAM
+}
+
+// VolumeCheck is an EBS volume attached to a scanned instance
+type VolumeCheck struct {
+	InstanceID string     `json:"instanceId"`
+	Region     string     `json:"region"`
+	Volume     VolumeInfo `json:"volume"`
+}
+
+// CheckResults is the optional "checks" section of a scan result
+type CheckResults struct {
+	Volumes          []VolumeCheck `json:"volumes,omitempty"`
+	UnencryptedCount int           `json:"unencryptedCount"`
+	// IAMFindingCount counts the instances with an IAM finding
+	IAMFindingCount int `json:"iamFindingCount"`
+}
+
+// merge appends the checks of another region
+func (c *CheckResults) merge(other *CheckResults) {
+	c.Volumes = append(c.Volumes, other.Volumes...)
+	c.UnencryptedCount += other.UnencryptedCount
+	c.IAMFindingCount += other.IAMFindingCount
+}
+
+// runChecks runs the requested optional checks on the instances of a region.
+// Per-instance findings are set on the instances of result. A failing check is
+// recorded in the errors of result, like the other enrichments of the scan.
+func (a *App) runChecks(ctx context.Context, cfg aws.Config, result *AWSResult, opts CheckOptions) *CheckResults {
+	instances := result.Instances
+	checks := &CheckResults{}
+	if opts.Volumes {
+		volumes, err := volumeChecks(ctx, ec2.NewFromConfig(cfg), cfg.Region, instances)
+		if err != nil {
+			result.addError(cfg.Region, "ec2", fmt.Errorf("failed to check the volumes: %w", err))
+		}
+		checks.Volumes = volumes
+		for _, v := range volumes {
+			if !v.Volume.Encrypted {
+				checks.UnencryptedCount++
+			}
+		}
+	}
+	if opts.IAM {
+		if err := resolveInstanceRoles(ctx, cfg, instances); err != nil {
+			result.addError(cfg.Region, "iam", fmt.Errorf("failed to check the instance roles: %w", err))
+		}
+		for _, inst := range instances {
+			if inst.IAMFinding != "" {
+				checks.IAMFindingCount++
+			}
+		}
+	}
+	return checks
+}
+
+// describeVolumesBatchSize keeps the attachment filter within the API limits
+const describeVolumesBatchSize = 200
+
+// volumeChecks lists the volumes attached to the instances
+func volumeChecks(ctx context.Context, client *ec2.Client, region string, instances []EC2Instance) ([]VolumeCheck, error) {
+	var ids []string
+	for _, inst := range instances {
+		if inst.InstanceID != "" {
+			ids = append(ids, inst.InstanceID)
+		}
+	}
+
+	var checks []VolumeCheck
+	for start := 0; start < len(ids); start += describeVolumesBatchSize {
+		end := min(start+describeVolumesBatchSize, len(ids))
+		pager := ec2.NewDescribeVolumesPaginator(client, &ec2.DescribeVolumesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("attachment.instance-id"), Values: ids[start:end]},
+			},
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe volumes: %w", err)
+			}
+			for _, v := range page.Volumes {
+				for _, att := range v.Attachments {
+					checks = append(checks, VolumeCheck{
+						InstanceID: aws.ToString(att.InstanceId),
+						Region:     region,
+						Volume: VolumeInfo{
+							DeviceName:          aws.ToString(att.Device),
+							VolumeID:            aws.ToString(v.VolumeId),
+							SizeGiB:             aws.ToInt32(v.Size),
+							VolumeType:          string(v.VolumeType),
+							Encrypted:           aws.ToBool(v.Encrypted),
+							DeleteOnTermination: aws.ToBool(att.DeleteOnTermination),
+						},
+					})
+				}
+			}
+		}
+	}
+	return checks, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// CLI output formats
+const (
+	OutputJSON  = "json"
+	OutputTable = "table"
+	OutputCSV   = "csv"
+)
+
+// runCLI runs the headless scan with the command line arguments and returns the exit
+// code: 0 when the scan succeeds, 1 when it fails, exitFindings when --fail-on checks
+// or fail rules of the policy match
+func runCLI(args []string) int {
+	cmd := newCLICommand()
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	var findings *findingsError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &findings):
+		fmt.Fprintln(os.Stderr, err)
+		return exitFindings
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+}
+
+// cliOptions are the flags of the gocheckami command
+type cliOptions struct {
+	profile        string
+	regions        []string
+	filter         string
+	output         string
+	failOn         []string
+	staleAfterDays int
+	policyFile     string
+	regoPolicy     string
+	logLevel       string
+	maxRPS         float64
+	// goldenAMIs and goldenAMIParameter are the golden AMI allow-list of non-golden-ami
+	goldenAMIs         []string
+	goldenAMIParameter string
+}
+
+func newCLICommand() *cobra.Command {
+	opts := cliOptions{}
+	cmd := &cobra.Command{
+		Use:   "gocheckami",
+		Short: "Scan the EC2 instances and SSM parameters of an AWS profile",
+		Long: `gocheckami runs the scan of the goCheckAmi GUI from the command line, e.g. in CI:
+the instances of the profile and their AMIs (age, staleness, deprecation),
+and the SSM parameters matching the filter. Run without arguments to open the GUI.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runScan(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.profile, "profile", os.Getenv("AWS_PROFILE"), "AWS profile to scan (default $AWS_PROFILE, else default)")
+	flags.StringSliceVar(&opts.regions, "region", nil, "regions to scan, repeated or comma-separated, or all (default the profile's region)")
+	flags.StringVar(&opts.filter, "filter", "", "SSM parameter name prefixes, comma-separated")
+	flags.StringVarP(&opts.output, "output", "o", OutputTable, "output format: json, table or csv (csv lists the instances only)")
+	flags.StringSliceVar(&opts.failOn, "fail-on", nil, "exit with code 2 when an instance matches one of these checks: "+
+		"stale-ami, deprecated-ami, deregistered-ami, public-ami, non-golden-ami, imdsv1")
+	flags.IntVar(&opts.staleAfterDays, "stale-after-days", defaultStaleAfterDays, "age in days after which an AMI is stale")
+	flags.StringVar(&opts.policyFile, "policy", "", "YAML policy to evaluate, exiting with code 2 on fail results (default the policy file of the settings)")
+	flags.StringVar(&opts.logLevel, "log-level", "warn", "log level on stderr and in the log file: debug, info, warn or error")
+	flags.Float64Var(&opts.maxRPS, "max-rps", 0, "cap on the Describe calls per second, to stay under the account's API throttling (default the rate limit of the settings)")
+	flags.StringVar(&opts.regoPolicy, "rego", "", "Rego policy file or directory to evaluate with opa, like --policy (default the Rego policies of the settings)")
+	flags.StringSliceVar(&opts.goldenAMIs, "golden-ami", nil, "golden AMI IDs, repeated or comma-separated, checked by non-golden-ami (default the golden AMIs of the settings)")
+	flags.StringVar(&opts.goldenAMIParameter, "golden-ami-parameter", "", "SSM parameter listing golden AMI IDs, like --golden-ami (default the golden AMI parameter of the settings)")
+	return cmd
+}
+
+// runScan scans like the GUI, always bypassing the result cache, writes the result
+// to w and fails with a findingsError when instances match the --fail-on checks.
+// Rego policies that fail to evaluate fail the scan once the result is written.
+func runScan(ctx context.Context, w io.Writer, opts cliOptions) error {
+	if opts.output != OutputJSON && opts.output != OutputTable && opts.output != OutputCSV {
+		return fmt.Errorf("unknown output %q: must be %s, %s or %s", opts.output, OutputJSON, OutputTable, OutputCSV)
+	}
+	if err := setLogLevel(opts.logLevel); err != nil {
+		return err
+	}
+	if err := validateFailOn(opts.failOn); err != nil {
+		return err
+	}
+	if opts.profile == "" {
+		opts.profile = "default"
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	a, err := newCLIApp(ctx, &opts)
+	if err != nil {
+		return err
+	}
+	defer a.closeStore()
+	if slices.Contains(opts.failOn, "non-golden-ami") && len(opts.goldenAMIs) == 0 && opts.goldenAMIParameter == "" {
+		return errors.New("--fail-on non-golden-ami needs golden AMIs: set --golden-ami, --golden-ami-parameter or the golden AMIs of the settings")
+	}
+	if opts.maxRPS != 0 {
+		if err := a.setRateLimit(opts.maxRPS); err != nil {
+			return err
+		}
+	}
+
+	result, err := a.ProcessRequest(ProcessingRequest{
+		Profile:            opts.profile,
+		Regions:            opts.regions,
+		Filter:             SSMFilter{NamePrefix: opts.filter},
+		ForceRefresh:       true,
+		StaleAfterDays:     opts.staleAfterDays,
+		GoldenAMIs:         opts.goldenAMIs,
+		GoldenAMIParameter: opts.goldenAMIParameter,
+	})
+	if err != nil {
+		return err
+	}
+	// A CI gate must not pass when its Rego policies were not evaluated
+	var regoErr error
+	for _, e := range result.Errors {
+		if e.Service == regoService {
+			regoErr = errors.New(e.Error)
+			continue
+		}
+		if e.Service == "" {
+			fmt.Fprintf(os.Stderr, "Warning: region %s: %s\n", e.Region, e.Error)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: region %s, %s: %s\n", e.Region, e.Service, e.Error)
+		}
+	}
+	if err := writeResult(w, result, opts.output); err != nil {
+		return err
+	}
+	if regoErr != nil {
+		return regoErr
+	}
+	findings := append(failOnFindings(result, opts.failOn), policyFindings(result)...)
+	if len(findings) > 0 {
+		return &findingsError{findings: findings}
+	}
+	return nil
+}
+
+// newCLIApp sets up the app like startup does, without the Wails runtime:
+// MFA codes are read from the terminal and no background scheduler runs.
+// The policies and golden AMIs of opts replace the ones of the settings, which
+// fill in the options left unset.
+func newCLIApp(ctx context.Context, opts *cliOptions) (*App, error) {
+	a := NewApp()
+	a.ctx = ctx
+	a.SetMFAPromptProvider(promptMFATokenStdin)
+	if prefs, err := a.LoadUserPrefs(); err == nil {
+		if err := a.setRetryPrefs(prefs); err != nil {
+			return nil, err
+		}
+		if err := a.setRateLimit(prefs.MaxRequestsPerSecond); err != nil {
+			return nil, err
+		}
+		a.setAWSFiles(prefs.AWSConfigFile, prefs.AWSCredentialsFile)
+		if opts.policyFile == "" {
+			opts.policyFile = prefs.PolicyFile
+		}
+		if opts.regoPolicy == "" {
+			opts.regoPolicy = prefs.RegoPolicyPath
+		}
+		if len(opts.goldenAMIs) == 0 && opts.goldenAMIParameter == "" {
+			opts.goldenAMIs, opts.goldenAMIParameter = prefs.GoldenAMIs, prefs.GoldenAMIParameter
+		}
+	}
+	if err := a.setPolicyFile(opts.policyFile); err != nil {
+		return nil, err
+	}
+	if err := a.setRegoPolicy(opts.regoPolicy); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// promptMFATokenStdin asks for the MFA code on stderr and reads it from stdin
+func promptMFATokenStdin(prompt MFAPrompt) (string, error) {
+	fmt.Fprintf(os.Stderr, "MFA code for profile %s (%s): ", prompt.Profile, prompt.SerialNumber)
+	code, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && code == "" {
+		return "", fmt.Errorf("failed to read MFA code: %w", err)
+	}
+	return strings.TrimSpace(code), nil
+}
+
+// writeResult writes the result as indented JSON, as tables of the instances and
+// parameters, or as a CSV of the instances
+func writeResult(w io.Writer, result *AWSResult, output string) error {
+	switch output {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		return nil
+	case OutputCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(columnNames(instanceColumns))
+		cw.WriteAll(columnRows(instanceColumns, result.Instances))
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+	}
+
+	columns, err := selectColumns(instanceColumns, clipboardTableColumns)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Instances (%d)\n", len(result.Instances))
+	if err := writeTable(w, columnNames(columns), columnRows(columns, result.Instances)); err != nil {
+		return err
+	}
+	if len(result.Parameters) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "\nParameters (%d)\n", len(result.Parameters))
+	return writeTable(w, columnNames(parameterColumns), columnRows(parameterColumns, result.Parameters))
+}
+
+// writeTable writes a header and rows as aligned text columns
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to format table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build !bindings && !dev
+
+package main
+
+// cliEnabled runs the CLI when the binary gets arguments
+const cliEnabled = true
@@ -0,0 +1,6 @@
+//go:build bindings || dev
+
+package main
+
+// cliEnabled is off in the bindings and dev builds: Wails passes them its own flags
+const cliEnabled = false
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// What CopyToClipboard copies
+const (
+	ClipInstanceIDs    = "instanceIds"
+	ClipAMIIDs         = "amiIds"
+	ClipParameterNames = "parameterNames"
+	ClipTable          = "table"
+)
+
+// Clipboard formats
+const (
+	ClipText = "text"
+	ClipJSON = "json"
+)
+
+// clipboardTableColumns are the instance columns of a copied table when none are selected,
+// narrow enough to stay readable once pasted in a chat
+var clipboardTableColumns = []string{"instanceId", "name", "state", "region", "ami", "amiAgeDays", "amiStatus"}
+
+// ClipboardRequest selects what to copy from a result. The frontend passes a
+// result holding only the selected rows.
+type ClipboardRequest struct {
+	Result AWSResult `json:"result"`
+	// Kind is instanceIds, amiIds, parameterNames or table
+	Kind string `json:"kind"`
+	// Format is text (default) or json
+	Format string `json:"format"`
+	// Columns are the instance columns of a table, see AvailableCSVColumns
+	Columns []string `json:"columns"`
+}
+
+// CopyToClipboard copies instance IDs, AMI IDs, parameter names or a table of the
+// instances to the system clipboard, and returns how many items were copied
+func (a *App) CopyToClipboard(req ClipboardRequest) (int, error) {
+	text, count, err := clipboardText(req)
+	if err != nil {
+		return 0, err
+	}
+	if err := runtime.ClipboardSetText(a.ctx, text); err != nil {
+		return 0, fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return count, nil
+}
+
+// clipboardText renders the selection in the requested format
+func clipboardText(req ClipboardRequest) (string, int, error) {
+	format := req.Format
+	if format == "" {
+		format = ClipText
+	}
+	if format != ClipText && format != ClipJSON {
+		return "", 0, fmt.Errorf("unknown clipboard format %q: must be %s or %s", format, ClipText, ClipJSON)
+	}
+
+	var values []string
+	switch req.Kind {
+	case ClipInstanceIDs:
+		for _, inst := range req.Result.Instances {
+			values = append(values, inst.InstanceID)
+		}
+	case ClipAMIIDs:
+		values = distinctAMIs(req.Result.Instances)
+	case ClipParameterNames:
+		for _, p := range req.Result.Parameters {
+			values = append(values, p.Name)
+		}
+	case ClipTable:
+		return clipboardTable(req.Result.Instances, req.Columns, format)
+	default:
+		return "", 0, fmt.Errorf("unknown clipboard selection %q", req.Kind)
+	}
+
+	if format == ClipJSON {
+		if values == nil {
+			values = []string{}
+		}
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to encode selection: %w", err)
+		}
+		return string(data), len(values), nil
+	}
+	return strings.Join(values, "\n"), len(values), nil
+}
+
+// clipboardTable renders the instances as an aligned text table, or as a JSON
+// array of objects keyed by column name
+func clipboardTable(instances []EC2Instance, names []string, format string) (string, int, error) {
+	if len(names) == 0 {
+		names = clipboardTableColumns
+	}
+	columns, err := selectColumns(instanceColumns, names)
+	if err != nil {
+		return "", 0, err
+	}
+	header, rows := columnNames(columns), columnRows(columns, instances)
+
+	if format == ClipJSON {
+		objects := make([]map[string]string, len(rows))
+		for i, row := range rows {
+			objects[i] = make(map[string]string, len(header))
+			for j, name := range header {
+				objects[i][name] = row[j]
+			}
+		}
+		data, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to encode table: %w", err)
+		}
+		return string(data), len(rows), nil
+	}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, header, rows); err != nil {
+		return "", 0, err
+	}
+	return strings.TrimRight(buf.String(), "\n"), len(rows), nil
+}
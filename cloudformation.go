@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/smithy-go"
+	"golang.org/x/sync/errgroup"
+)
+
+// Tags CloudFormation puts on the instances it creates
+const (
+	stackNameTag      = "aws:cloudformation:stack-name"
+	stackLogicalIDTag = "aws:cloudformation:logical-id"
+)
+
+// maxStackWorkers bounds the concurrent DescribeStackResources calls
+const maxStackWorkers = 4
+
+// mapInstanceStacks completes the stack of the instances without stack tags
+// (tags can be removed, or not propagated by hand-made templates) with
+// DescribeStackResources, and marks the ones no stack owns as unmanaged
+func mapInstanceStacks(ctx context.Context, client *cloudformation.Client, instances []EC2Instance) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxStackWorkers)
+	for i := range instances {
+		inst := &instances[i]
+		if inst.StackName != "" {
+			continue
+		}
+		g.Go(func() error {
+			out, err := client.DescribeStackResources(ctx, &cloudformation.DescribeStackResourcesInput{
+				PhysicalResourceId: aws.String(inst.InstanceID),
+			})
+			if err != nil {
+				if isStackNotFound(err) {
+					inst.Unmanaged = true
+					return nil
+				}
+				return fmt.Errorf("failed to describe stack resources of %s: %w", inst.InstanceID, err)
+			}
+			// Each goroutine only writes its own instance
+			if len(out.StackResources) == 0 {
+				inst.Unmanaged = true
+				return nil
+			}
+			inst.StackName = aws.ToString(out.StackResources[0].StackName)
+			inst.StackLogicalID = aws.ToString(out.StackResources[0].LogicalResourceId)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// isStackNotFound reports whether err is the ValidationError of a resource that no
+// stack owns, "Stack for i-... does not exist"
+func isStackNotFound(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+		strings.Contains(apiErr.ErrorMessage(), "does not exist")
+}
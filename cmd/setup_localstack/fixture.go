@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is the dataset seeded into LocalStack, read from a YAML or JSON file
+type Fixture struct {
+	// Region defaults to us-east-1; the -region flag overrides it
+	Region     string      `yaml:"region" json:"region"`
+	Parameters []Parameter `yaml:"parameters" json:"parameters"`
+	AMIs       []AMI       `yaml:"amis" json:"amis"`
+	Instances  []Instance  `yaml:"instances" json:"instances"`
+}
+
+// Parameter is an SSM parameter; Type defaults to String
+type Parameter struct {
+	Name        string            `yaml:"name" json:"name"`
+	Value       string            `yaml:"value" json:"value"`
+	Type        string            `yaml:"type" json:"type"`
+	Description string            `yaml:"description" json:"description"`
+	Tags        map[string]string `yaml:"tags" json:"tags"`
+}
+
+// AMI is an image registered in LocalStack. Instances refer to it by Key.
+type AMI struct {
+	Key         string            `yaml:"key" json:"key"`
+	Name        string            `yaml:"name" json:"name"`
+	Description string            `yaml:"description" json:"description"`
+	Tags        map[string]string `yaml:"tags" json:"tags"`
+}
+
+// Instance is launched Count times (default 1) from AMI: the key of a fixture
+// AMI or the ID of an existing image, e.g. one of the LocalStack mock AMIs.
+// InstanceType defaults to t2.micro.
+type Instance struct {
+	Name         string            `yaml:"name" json:"name"`
+	AMI          string            `yaml:"ami" json:"ami"`
+	InstanceType string            `yaml:"instanceType" json:"instanceType"`
+	Count        int32             `yaml:"count" json:"count"`
+	Tags         map[string]string `yaml:"tags" json:"tags"`
+}
+
+// loadFixture reads a fixture, as JSON for a .json file and as YAML otherwise
+func loadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var f Fixture
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// validate checks the fixture and fills in the defaults
+func (f *Fixture) validate() error {
+	if f.Region == "" {
+		f.Region = "us-east-1"
+	}
+	for i := range f.Parameters {
+		p := &f.Parameters[i]
+		if p.Name == "" || p.Value == "" {
+			return fmt.Errorf("parameter %d: name and value are required", i+1)
+		}
+		if p.Type == "" {
+			p.Type = string(ssmtypes.ParameterTypeString)
+		}
+		if !validParameterType(p.Type) {
+			return fmt.Errorf("parameter %s: invalid type %q: must be String, StringList or SecureString", p.Name, p.Type)
+		}
+	}
+
+	keys := make(map[string]bool)
+	for i, ami := range f.AMIs {
+		if ami.Key == "" || ami.Name == "" {
+			return fmt.Errorf("AMI %d: key and name are required", i+1)
+		}
+		if keys[ami.Key] {
+			return fmt.Errorf("AMI key %q is used twice", ami.Key)
+		}
+		keys[ami.Key] = true
+	}
+
+	for i := range f.Instances {
+		inst := &f.Instances[i]
+		if inst.Name == "" {
+			return fmt.Errorf("instance %d: name is required", i+1)
+		}
+		if !keys[inst.AMI] && !strings.HasPrefix(inst.AMI, "ami-") {
+			return fmt.Errorf("instance %s: AMI %q is neither a fixture AMI key nor an image ID", inst.Name, inst.AMI)
+		}
+		if inst.InstanceType == "" {
+			inst.InstanceType = "t2.micro"
+		}
+		if inst.Count == 0 {
+			inst.Count = 1
+		}
+		if inst.Count < 0 {
+			return fmt.Errorf("instance %s: count must be positive, got %d", inst.Name, inst.Count)
+		}
+	}
+	return nil
+}
+
+// hasAMI reports whether key is the key of a fixture AMI
+func (f *Fixture) hasAMI(key string) bool {
+	for _, ami := range f.AMIs {
+		if ami.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func validParameterType(t string) bool {
+	for _, valid := range ssmtypes.ParameterTypeString.Values() {
+		if t == string(valid) {
+			return true
+		}
+	}
+	return false
+}
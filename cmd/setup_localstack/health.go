@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// healthPollInterval is the delay between two polls of the health endpoint
+const healthPollInterval = time.Second
+
+// requiredServices are the LocalStack services the seeding calls
+var requiredServices = []string{"ssm", "ec2"}
+
+// localStackHealth is the body of /_localstack/health
+type localStackHealth struct {
+	Services map[string]string `json:"services"`
+}
+
+// waitForLocalStack polls the health endpoint until SSM and EC2 are ready, failing
+// with the last reason once timeout elapses
+func waitForLocalStack(ctx context.Context, endpoint string, timeout time.Duration) error {
+	healthURL := strings.TrimSuffix(endpoint, "/") + "/_localstack/health"
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Printf("Waiting for LocalStack at %s...\n", endpoint)
+	for {
+		err := checkHealth(ctx, healthURL)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = ctx.Err()
+			}
+			return fmt.Errorf("LocalStack at %s isn't ready after %s, is it running? %w", endpoint, timeout, err)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// checkHealth fails unless the health endpoint reports the required services
+// as available or running
+func checkHealth(ctx context.Context, healthURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health endpoint returned %s", resp.Status)
+	}
+
+	var health localStackHealth
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return fmt.Errorf("failed to decode health: %w", err)
+	}
+	for _, service := range requiredServices {
+		switch status := health.Services[service]; status {
+		case "available", "running":
+		case "":
+			return fmt.Errorf("service %s isn't enabled", service)
+		default:
+			return fmt.Errorf("service %s is %s", service, status)
+		}
+	}
+	return nil
+}
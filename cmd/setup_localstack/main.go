@@ -2,31 +2,56 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
-	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 )
 
 func main() {
+	fixturePath := flag.String("fixture", "cmd/setup_localstack/fixture.yaml", "YAML or JSON fixture describing the parameters, AMIs and instances to seed")
+	endpoint := flag.String("endpoint", "http://localhost:4566", "LocalStack endpoint")
+	region := flag.String("region", "", "region to seed (default the region of the fixture)")
+	teardown := flag.Bool("teardown", false, "delete the seeded parameters, AMIs and instances instead of seeding")
+	reset := flag.Bool("reset", false, "delete the seeded resources, then seed the fixture again")
+	waitTimeout := flag.Duration("wait", time.Minute, "how long to wait for LocalStack's SSM and EC2 to be ready, 0 to skip the check")
+	flag.Parse()
+	if *teardown && *reset {
+		log.Fatal("-teardown and -reset can't be used together")
+	}
+
+	fixture, err := loadFixture(*fixturePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *region != "" {
+		fixture.Region = *region
+	}
+
 	ctx := context.TODO()
+	if *waitTimeout > 0 {
+		if err := waitForLocalStack(ctx, *endpoint, *waitTimeout); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Custom resolver for LocalStack
 	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 		return aws.Endpoint{
 			PartitionID:   "aws",
-			URL:           "http://localhost:4566",
-			SigningRegion: "us-east-1",
+			URL:           *endpoint,
+			SigningRegion: region,
 		}, nil
 	})
 
 	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion("us-east-1"),
+		config.WithRegion(fixture.Region),
 		config.WithEndpointResolverWithOptions(customResolver),
 		config.WithCredentialsProvider(aws.AnonymousCredentials{}),
 	)
@@ -34,63 +59,23 @@ func main() {
 		log.Fatalf("unable to load SDK config: %v", err)
 	}
 
-	// 1. Setup SSM
-	ssmClient := ssm.NewFromConfig(cfg)
-	params := map[string]string{
-		"/app/prod/db_url":  "jdbc:mysql://prod-db:3306/db",
-		"/app/prod/api_key": "secret-key-prod",
-		"/app/dev/db_url":   "jdbc:mysql://dev-db:3306/db",
-		"service-a-config":  "some-config",
-	}
-
-	fmt.Println("Populating SSM Parameters...")
-	for name, val := range params {
-		_, err := ssmClient.PutParameter(ctx, &ssm.PutParameterInput{
-			Name:      aws.String(name),
-			Value:     aws.String(val),
-			Type:      ssmtypes.ParameterTypeString,
-			Overwrite: aws.Bool(true),
-		})
-		if err != nil {
-			log.Printf("Failed to put parameter %s: %v", name, err)
-		} else {
-			fmt.Printf("Put parameter %s\n", name)
+	s := &seeder{ssm: ssm.NewFromConfig(cfg), ec2: ec2.NewFromConfig(cfg)}
+	if *teardown || *reset {
+		s.teardown(ctx)
+		if *teardown {
+			done("Done tearing down LocalStack.", s.failures)
+			return
 		}
 	}
+	s.seed(ctx, fixture)
+	done(fmt.Sprintf("Done populating LocalStack from %s.", *fixturePath), s.failures)
+}
 
-	// 2. Setup EC2
-	ec2Client := ec2.NewFromConfig(cfg)
-	fmt.Println("Launching EC2 Instances...")
-
-	instances := []struct {
-		Name string
-		AMI  string
-	}{
-		{"WebServer-Prod", "ami-12345678"},
-		{"Worker-Dev", "ami-87654321"},
-	}
-
-	for _, inst := range instances {
-		_, err := ec2Client.RunInstances(ctx, &ec2.RunInstancesInput{
-			ImageId:      aws.String(inst.AMI),
-			InstanceType: types.InstanceTypeT2Micro,
-			MinCount:     aws.Int32(1),
-			MaxCount:     aws.Int32(1),
-			TagSpecifications: []types.TagSpecification{
-				{
-					ResourceType: types.ResourceTypeInstance,
-					Tags: []types.Tag{
-						{Key: aws.String("Name"), Value: aws.String(inst.Name)},
-					},
-				},
-			},
-		})
-		if err != nil {
-			log.Printf("Failed to run instance %s: %v", inst.Name, err)
-		} else {
-			fmt.Printf("Launched instance %s\n", inst.Name)
-		}
+// done prints the outcome, exiting with 1 when some resources failed
+func done(msg string, failures int) {
+	if failures > 0 {
+		fmt.Printf("%s %d failure(s).\n", msg, failures)
+		os.Exit(1)
 	}
-
-	fmt.Println("Done populating LocalStack.")
+	fmt.Println(msg)
 }
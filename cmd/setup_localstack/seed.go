@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// markerTagKey and markerTagValue tag every seeded resource, so that the
+// teardown only deletes what the tool created
+const (
+	markerTagKey   = "seeded-by"
+	markerTagValue = "setup_localstack"
+)
+
+// withMarker returns tags plus the marker tag
+func withMarker(tags map[string]string) map[string]string {
+	marked := map[string]string{markerTagKey: markerTagValue}
+	for k, v := range tags {
+		marked[k] = v
+	}
+	return marked
+}
+
+// seeder creates the resources of a fixture. A resource that fails is logged and
+// counted, and the others are still created.
+type seeder struct {
+	ssm      *ssm.Client
+	ec2      *ec2.Client
+	failures int
+}
+
+func (s *seeder) fail(format string, args ...any) {
+	log.Printf(format, args...)
+	s.failures++
+}
+
+// seed creates the parameters, then the AMIs and the instances launched from them
+func (s *seeder) seed(ctx context.Context, f *Fixture) {
+	fmt.Println("Populating SSM Parameters...")
+	for _, p := range f.Parameters {
+		if err := s.putParameter(ctx, p); err != nil {
+			s.fail("Failed to put parameter %s: %v", p.Name, err)
+		} else {
+			fmt.Printf("Put parameter %s\n", p.Name)
+		}
+	}
+
+	fmt.Println("Registering AMIs...")
+	amis := make(map[string]string, len(f.AMIs))
+	for _, ami := range f.AMIs {
+		id, err := s.registerImage(ctx, ami)
+		if err != nil {
+			s.fail("Failed to register AMI %s: %v", ami.Name, err)
+			continue
+		}
+		amis[ami.Key] = id
+		fmt.Printf("Registered AMI %s as %s\n", ami.Name, id)
+	}
+
+	fmt.Println("Launching EC2 Instances...")
+	for _, inst := range f.Instances {
+		imageID := inst.AMI
+		if f.hasAMI(inst.AMI) {
+			id, ok := amis[inst.AMI]
+			if !ok {
+				s.fail("Skipping instance %s: its AMI %s failed to register", inst.Name, inst.AMI)
+				continue
+			}
+			imageID = id
+		}
+		if err := s.runInstances(ctx, inst, imageID); err != nil {
+			s.fail("Failed to run instance %s: %v", inst.Name, err)
+		} else {
+			fmt.Printf("Launched instance %s (%d x %s)\n", inst.Name, inst.Count, imageID)
+		}
+	}
+}
+
+// putParameter creates or overwrites a parameter. Tags can't be passed along with
+// Overwrite, so they and the marker tag are added afterwards.
+func (s *seeder) putParameter(ctx context.Context, p Parameter) error {
+	in := &ssm.PutParameterInput{
+		Name:      aws.String(p.Name),
+		Value:     aws.String(p.Value),
+		Type:      ssmtypes.ParameterType(p.Type),
+		Overwrite: aws.Bool(true),
+	}
+	if p.Description != "" {
+		in.Description = aws.String(p.Description)
+	}
+	if _, err := s.ssm.PutParameter(ctx, in); err != nil {
+		return err
+	}
+	marked := withMarker(p.Tags)
+	var tags []ssmtypes.Tag
+	for _, k := range sortedKeys(marked) {
+		tags = append(tags, ssmtypes.Tag{Key: aws.String(k), Value: aws.String(marked[k])})
+	}
+	_, err := s.ssm.AddTagsToResource(ctx, &ssm.AddTagsToResourceInput{
+		ResourceType: ssmtypes.ResourceTypeForTaggingParameter,
+		ResourceId:   aws.String(p.Name),
+		Tags:         tags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag: %w", err)
+	}
+	return nil
+}
+
+// registerImage registers an AMI, reusing the image of the same name left by a
+// previous run since AMI names are unique per account
+func (s *seeder) registerImage(ctx context.Context, ami AMI) (string, error) {
+	out, err := s.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Owners:  []string{"self"},
+		Filters: []types.Filter{{Name: aws.String("name"), Values: []string{ami.Name}}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look for an existing image: %w", err)
+	}
+
+	var id string
+	if len(out.Images) > 0 {
+		id = aws.ToString(out.Images[0].ImageId)
+	} else {
+		in := &ec2.RegisterImageInput{
+			Name:               aws.String(ami.Name),
+			Architecture:       types.ArchitectureValuesX8664,
+			RootDeviceName:     aws.String("/dev/xvda"),
+			VirtualizationType: aws.String("hvm"),
+		}
+		if ami.Description != "" {
+			in.Description = aws.String(ami.Description)
+		}
+		registered, err := s.ec2.RegisterImage(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		id = aws.ToString(registered.ImageId)
+	}
+
+	if _, err := s.ec2.CreateTags(ctx, &ec2.CreateTagsInput{Resources: []string{id}, Tags: ec2Tags(withMarker(ami.Tags))}); err != nil {
+		return "", fmt.Errorf("failed to tag %s: %w", id, err)
+	}
+	return id, nil
+}
+
+// runInstances launches the instances of inst from imageID, tagged with their name,
+// their tags and the marker tag
+func (s *seeder) runInstances(ctx context.Context, inst Instance, imageID string) error {
+	tags := withMarker(inst.Tags)
+	tags["Name"] = inst.Name
+	_, err := s.ec2.RunInstances(ctx, &ec2.RunInstancesInput{
+		ImageId:      aws.String(imageID),
+		InstanceType: types.InstanceType(inst.InstanceType),
+		MinCount:     aws.Int32(inst.Count),
+		MaxCount:     aws.Int32(inst.Count),
+		TagSpecifications: []types.TagSpecification{
+			{
+				ResourceType: types.ResourceTypeInstance,
+				Tags:         ec2Tags(tags),
+			},
+		},
+	})
+	return err
+}
+
+func ec2Tags(m map[string]string) []types.Tag {
+	var tags []types.Tag
+	for _, k := range sortedKeys(m) {
+		tags = append(tags, types.Tag{Key: aws.String(k), Value: aws.String(m[k])})
+	}
+	return tags
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// deleteParametersBatchSize is the most names DeleteParameters accepts
+const deleteParametersBatchSize = 10
+
+// teardown terminates the instances, deregisters the AMIs and deletes the
+// parameters carrying the marker tag, whatever fixture they were seeded from
+func (s *seeder) teardown(ctx context.Context) {
+	fmt.Println("Terminating seeded EC2 Instances...")
+	if ids, err := s.seededInstances(ctx); err != nil {
+		s.fail("Failed to list seeded instances: %v", err)
+	} else if len(ids) > 0 {
+		if _, err := s.ec2.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: ids}); err != nil {
+			s.fail("Failed to terminate instances: %v", err)
+		} else {
+			fmt.Printf("Terminated %d instance(s)\n", len(ids))
+		}
+	}
+
+	fmt.Println("Deregistering seeded AMIs...")
+	images, err := s.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Owners:  []string{"self"},
+		Filters: []types.Filter{markerFilter()},
+	})
+	if err != nil {
+		s.fail("Failed to list seeded AMIs: %v", err)
+	} else {
+		for _, img := range images.Images {
+			if _, err := s.ec2.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: img.ImageId}); err != nil {
+				s.fail("Failed to deregister AMI %s: %v", aws.ToString(img.Name), err)
+			} else {
+				fmt.Printf("Deregistered AMI %s\n", aws.ToString(img.Name))
+			}
+		}
+	}
+
+	fmt.Println("Deleting seeded SSM Parameters...")
+	names, err := s.seededParameters(ctx)
+	if err != nil {
+		s.fail("Failed to list seeded parameters: %v", err)
+		return
+	}
+	for start := 0; start < len(names); start += deleteParametersBatchSize {
+		batch := names[start:min(start+deleteParametersBatchSize, len(names))]
+		if _, err := s.ssm.DeleteParameters(ctx, &ssm.DeleteParametersInput{Names: batch}); err != nil {
+			s.fail("Failed to delete parameters %v: %v", batch, err)
+		} else {
+			fmt.Printf("Deleted %d parameter(s)\n", len(batch))
+		}
+	}
+}
+
+// markerFilter matches the resources carrying the marker tag
+func markerFilter() types.Filter {
+	return types.Filter{Name: aws.String("tag:" + markerTagKey), Values: []string{markerTagValue}}
+}
+
+// seededInstances returns the IDs of the seeded instances that aren't terminated yet
+func (s *seeder) seededInstances(ctx context.Context) ([]string, error) {
+	var ids []string
+	pager := ec2.NewDescribeInstancesPaginator(s.ec2, &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			markerFilter(),
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range page.Reservations {
+			for _, inst := range res.Instances {
+				ids = append(ids, aws.ToString(inst.InstanceId))
+			}
+		}
+	}
+	return ids, nil
+}
+
+// seededParameters returns the names of the parameters carrying the marker tag
+func (s *seeder) seededParameters(ctx context.Context) ([]string, error) {
+	var names []string
+	pager := ssm.NewDescribeParametersPaginator(s.ssm, &ssm.DescribeParametersInput{
+		ParameterFilters: []ssmtypes.ParameterStringFilter{
+			{Key: aws.String("tag:" + markerTagKey), Option: aws.String("Equals"), Values: []string{markerTagValue}},
+		},
+	})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			names = append(names, aws.ToString(p.Name))
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// credentialsExpiryMargin drops a cached config this long before its credentials
+// expire, so a scan doesn't start with credentials about to lapse
+const credentialsExpiryMargin = 5 * time.Minute
+
+// cachedConfig is an authenticated profile: its config and the identity it was validated with
+type cachedConfig struct {
+	cfg      aws.Config
+	identity *sts.GetCallerIdentityOutput
+}
+
+// clientKey identifies a service client: the credentials it signs with and its region
+type clientKey struct {
+	credentials *aws.CredentialsCache
+	region      string
+	service     string
+}
+
+// cachedConfigFor returns the authenticated config of a profile if its credentials
+// are still valid; expired or unretrievable ones invalidate the cache entry
+func (a *App) cachedConfigFor(ctx context.Context, profile string) *cachedConfig {
+	a.mu.Lock()
+	cached := a.configs[profile]
+	a.mu.Unlock()
+	if cached == nil {
+		return nil
+	}
+
+	creds, err := cached.cfg.Credentials.Retrieve(ctx)
+	if err != nil || (creds.CanExpire && time.Until(creds.Expires) < credentialsExpiryMargin) {
+		a.InvalidateProfile(profile)
+		return nil
+	}
+	return cached
+}
+
+// storeConfig caches the authenticated config of a profile
+func (a *App) storeConfig(profile string, cfg aws.Config, identity *sts.GetCallerIdentityOutput) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.configs[profile] = &cachedConfig{cfg: cfg, identity: identity}
+}
+
+// InvalidateProfile drops the cached config and clients of a profile,
+// so the next call reloads the config files and re-authenticates
+func (a *App) InvalidateProfile(profile string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cached, ok := a.configs[profile]
+	if !ok {
+		return
+	}
+	delete(a.configs, profile)
+	for key := range a.clients {
+		if aws.CredentialsProvider(key.credentials) == cached.cfg.Credentials {
+			delete(a.clients, key)
+		}
+	}
+}
+
+// invalidateAllProfiles drops every cached config and client
+func (a *App) invalidateAllProfiles() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.configs = make(map[string]*cachedConfig)
+	a.clients = make(map[clientKey]interface{})
+}
+
+// cachedClient returns the client of service for the credentials and region of cfg,
+// building it with newClient (a service's NewFromConfig) the first time.
+// Only the credentials of a cached profile config are cached, since InvalidateProfile
+// evicts their clients; other configs, such as the roles assumed in the accounts of
+// an organization, get a fresh client.
+func cachedClient[C, O any](a *App, cfg aws.Config, service string, newClient func(aws.Config, ...func(*O)) *C) *C {
+	creds, ok := cfg.Credentials.(*aws.CredentialsCache)
+	if !ok {
+		return newClient(cfg)
+	}
+	key := clientKey{credentials: creds, region: cfg.Region, service: service}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.profileCredentials(creds) {
+		return newClient(cfg)
+	}
+	if client, ok := a.clients[key].(*C); ok {
+		return client
+	}
+	client := newClient(cfg)
+	a.clients[key] = client
+	return client
+}
+
+// profileCredentials reports whether creds are those of a cached profile config.
+// a.mu must be held.
+func (a *App) profileCredentials(creds *aws.CredentialsCache) bool {
+	for _, cached := range a.configs {
+		if cached.cfg.Credentials == aws.CredentialsProvider(creds) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// reportColumn is a named column of a report, with the getter of its cell
+type reportColumn[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// instanceColumns are the instance columns of the reports, in their default order
+var instanceColumns = []reportColumn[EC2Instance]{
+	{"instanceId", func(i EC2Instance) string { return i.InstanceID }},
+	{"name", func(i EC2Instance) string { return i.Name }},
+	{"state", func(i EC2Instance) string { return i.State }},
+	{"instanceType", func(i EC2Instance) string { return i.InstanceType }},
+	{"accountId", func(i EC2Instance) string { return i.AccountID }},
+	{"region", func(i EC2Instance) string { return i.Region }},
+	{"availabilityZone", func(i EC2Instance) string { return i.AvailabilityZone }},
+	{"platform", func(i EC2Instance) string { return i.Platform }},
+	{"lifecycle", func(i EC2Instance) string { return i.Lifecycle }},
+	{"ami", func(i EC2Instance) string { return i.AMI }},
+	{"amiName", func(i EC2Instance) string { return i.AMIName }},
+	{"amiCreationDate", func(i EC2Instance) string { return i.AMICreationDate }},
+	{"amiAgeDays", func(i EC2Instance) string { return strconv.Itoa(i.AMIAgeDays) }},
+	{"amiStale", func(i EC2Instance) string { return strconv.FormatBool(i.AMIStale) }},
+	{"amiStatus", func(i EC2Instance) string { return i.AMIStatus }},
+	{"amiPublic", func(i EC2Instance) string { return strconv.FormatBool(i.AMIPublic) }},
+	{"recommendedAmi", func(i EC2Instance) string { return i.RecommendedAMI }},
+	{"nonCompliant", func(i EC2Instance) string { return strconv.FormatBool(i.NonCompliant) }},
+	{"imdsV1Allowed", func(i EC2Instance) string { return strconv.FormatBool(i.IMDSv1Allowed) }},
+	{"iamFinding", func(i EC2Instance) string { return i.IAMFinding }},
+	{"monthlyCostUsd", func(i EC2Instance) string { return strconv.FormatFloat(i.MonthlyCostUSD, 'f', 2, 64) }},
+	{"stackName", func(i EC2Instance) string { return i.StackName }},
+	{"loadBalancers", func(i EC2Instance) string { return strings.Join(i.LoadBalancers, " ") }},
+	{"launchTime", func(i EC2Instance) string { return i.LaunchTime }},
+}
+
+// parameterColumns are the parameter columns of the reports, in their default order
+var parameterColumns = []reportColumn[ParameterInfo]{
+	{"name", func(p ParameterInfo) string { return p.Name }},
+	{"region", func(p ParameterInfo) string { return p.Region }},
+	{"type", func(p ParameterInfo) string { return p.Type }},
+	{"version", func(p ParameterInfo) string { return strconv.FormatInt(p.Version, 10) }},
+	{"tier", func(p ParameterInfo) string { return p.Tier }},
+	{"lastModifiedDate", func(p ParameterInfo) string { return p.LastModifiedDate }},
+	{"lastModifiedUser", func(p ParameterInfo) string { return p.LastModifiedUser }},
+}
+
+// CSVColumns selects the columns of ExportCSV; empty selects every column
+type CSVColumns struct {
+	Instances  []string `json:"instances"`
+	Parameters []string `json:"parameters"`
+}
+
+// AvailableCSVColumns lists the columns ExportCSV accepts, in their default order
+func (a *App) AvailableCSVColumns() CSVColumns {
+	return CSVColumns{Instances: columnNames(instanceColumns), Parameters: columnNames(parameterColumns)}
+}
+
+// ExportResultCSV asks where to save and writes the result with ExportCSV.
+// It returns the files written, or nothing if the user cancelled the dialog.
+func (a *App) ExportResultCSV(result AWSResult, columns CSVColumns) ([]string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export scan results",
+		DefaultFilename: "scan.csv",
+		Filters:         []runtime.FileFilter{{DisplayName: "CSV", Pattern: "*.csv"}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return a.ExportCSV(result, path, columns)
+}
+
+// ExportCSV flattens the instances and parameters of a result into two CSV files
+// next to path, <name>-instances.csv and <name>-parameters.csv, and returns them
+func (a *App) ExportCSV(result AWSResult, path string, columns CSVColumns) ([]string, error) {
+	instCols, err := selectColumns(instanceColumns, columns.Instances)
+	if err != nil {
+		return nil, err
+	}
+	paramCols, err := selectColumns(parameterColumns, columns.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(path, ".csv")
+	instPath, paramPath := base+"-instances.csv", base+"-parameters.csv"
+	if err := writeCSV(instPath, columnNames(instCols), columnRows(instCols, result.Instances)); err != nil {
+		return nil, err
+	}
+	if err := writeCSV(paramPath, columnNames(paramCols), columnRows(paramCols, result.Parameters)); err != nil {
+		return nil, err
+	}
+	return []string{instPath, paramPath}, nil
+}
+
+// selectColumns returns the named columns in the requested order, or all of them
+func selectColumns[T any](all []reportColumn[T], names []string) ([]reportColumn[T], error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	byName := make(map[string]reportColumn[T], len(all))
+	for _, c := range all {
+		byName[c.name] = c
+	}
+	selected := make([]reportColumn[T], 0, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q: must be one of %s", name, strings.Join(columnNames(all), ", "))
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+func columnNames[T any](columns []reportColumn[T]) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// columnRows renders the items as rows of the columns
+func columnRows[T any](columns []reportColumn[T], items []T) [][]string {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			row[j] = c.value(item)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// writeCSV writes a header and rows to path
+func writeCSV(path string, header []string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Demo account: the profile listed in demo mode, its account and regions,
+// the first region being the profile's default one
+const (
+	demoProfile   = "demo"
+	demoAccountID = "123456789012"
+)
+
+var demoRegions = []string{"us-east-1", "eu-west-1", "ap-southeast-2"}
+
+// errDemoMode fails the calls the demo account has no data for, instead of
+// sending them to AWS without credentials
+var errDemoMode = errors.New("not available in demo mode")
+
+// DemoDataProvider serves a synthetic account through the client interfaces, so
+// the app can be demoed without AWS credentials: a few instances per region on
+// fresh, stale, deprecated, public and deregistered AMIs, some parameters and secrets.
+// The data only depends on the region and on when the provider was created.
+type DemoDataProvider struct {
+	// now anchors the AMI ages and the parameter dates
+	now    time.Time
+	region string
+}
+
+// NewDemoDataProvider creates a DemoDataProvider whose AMI ages count from now
+func NewDemoDataProvider() DemoDataProvider {
+	return DemoDataProvider{now: time.Now().UTC()}
+}
+
+func (d DemoDataProvider) SSM(cfg aws.Config) SSMAPI {
+	d.region = cfg.Region
+	return d
+}
+
+func (d DemoDataProvider) EC2(cfg aws.Config) EC2API {
+	d.region = cfg.Region
+	return d
+}
+
+func (d DemoDataProvider) SecretsManager(cfg aws.Config) SecretsManagerAPI {
+	d.region = cfg.Region
+	return d
+}
+
+func (d DemoDataProvider) STS(cfg aws.Config) STSAPI {
+	d.region = cfg.Region
+	return d
+}
+
+// config is the config of the demo profile. Its HTTP client fails every request,
+// without retries, so the features the demo account doesn't serve report an
+// error instead of calling AWS anonymously.
+func (d DemoDataProvider) config() aws.Config {
+	return aws.Config{
+		Region:      demoRegions[0],
+		Credentials: aws.AnonymousCredentials{},
+		Retryer:     func() aws.Retryer { return aws.NopRetryer{} },
+		HTTPClient: aws.HTTPClient(httpClientFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errDemoMode
+		})),
+	}
+}
+
+// httpClientFunc adapts a function to an HTTP client of the SDK
+type httpClientFunc func(*http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// demoAMI is an image of the demo account; deregistered ones are used by
+// instances but unknown to DescribeImages. Each image has one snapshot of
+// volumeGiB, archived for the archived ones.
+type demoAMI struct {
+	name         string
+	description  string
+	alias        string
+	ageDays      int
+	volumeGiB    int32
+	deprecated   bool
+	public       bool
+	archived     bool
+	deregistered bool
+}
+
+var demoAMIs = []demoAMI{
+	{name: "al2023-ami-2023.6.20240918.0-kernel-6.1-x86_64", description: "Amazon Linux 2023 AMI", alias: "amazon", ageDays: 24, volumeGiB: 8, public: true},
+	{name: "golden-base-ubuntu-22.04-v41", description: "Hardened Ubuntu 22.04 base image", ageDays: 38, volumeGiB: 20},
+	{name: "golden-base-ubuntu-22.04-v33", description: "Hardened Ubuntu 22.04 base image", ageDays: 212, volumeGiB: 20, archived: true},
+	{name: "amzn2-ami-hvm-2.0.20230119.1-x86_64-gp2", description: "Amazon Linux 2 AMI", alias: "amazon", ageDays: 637, volumeGiB: 8, deprecated: true, public: true},
+	{name: "windows-2019-legacy-billing", description: "Legacy billing server", ageDays: 96, volumeGiB: 60, public: true},
+	{name: "golden-base-ubuntu-20.04-v12", ageDays: 540, volumeGiB: 20, deregistered: true},
+}
+
+// demoInstance is an instance of the demo account, running demoAMIs[ami]
+type demoInstance struct {
+	name, team, env string
+	instanceType    string
+	state           ec2types.InstanceStateName
+	ami             int
+	launchedDaysAgo int
+	spot            bool
+	imdsv1          bool
+	groups          []string
+}
+
+var demoInstances = []demoInstance{
+	{name: "web-1", team: "storefront", env: "production", instanceType: "t3.medium", state: "running", ami: 1, launchedDaysAgo: 30, groups: []string{"web"}},
+	{name: "web-2", team: "storefront", env: "production", instanceType: "t3.medium", state: "running", ami: 1, launchedDaysAgo: 30, groups: []string{"web"}},
+	{name: "api-1", team: "platform", env: "production", instanceType: "m6i.large", state: "running", ami: 2, launchedDaysAgo: 190, groups: []string{"internal"}},
+	{name: "worker-spot-1", team: "data", env: "production", instanceType: "c6i.xlarge", state: "running", ami: 0, launchedDaysAgo: 3, spot: true, groups: []string{"internal"}},
+	{name: "bastion", team: "platform", env: "shared", instanceType: "t3.micro", state: "running", ami: 3, launchedDaysAgo: 600, imdsv1: true, groups: []string{"ssh"}},
+	{name: "billing-legacy", team: "finance", env: "production", instanceType: "m5.xlarge", state: "running", ami: 4, launchedDaysAgo: 90, imdsv1: true, groups: []string{"rdp", "internal"}},
+	{name: "staging-api-1", team: "platform", env: "staging", instanceType: "t3.large", state: "stopped", ami: 5, launchedDaysAgo: 500, groups: []string{"internal"}},
+	{name: "ci-runner", team: "platform", env: "shared", instanceType: "c6i.2xlarge", state: "running", ami: 0, launchedDaysAgo: 10, groups: []string{"internal"}},
+}
+
+// demoGroups are the security groups, by name, with the ports they open to the internet
+var demoGroups = map[string][]int32{
+	"web":      {80, 443},
+	"ssh":      {22},
+	"rdp":      {3389},
+	"internal": nil,
+}
+
+// demoParameter is a parameter of the demo account
+type demoParameter struct {
+	name         string
+	paramType    ssmtypes.ParameterType
+	value        string
+	version      int64
+	modifiedDays int
+}
+
+// demoParameters returns the parameters of the region; the golden AMI parameter
+// points at the latest golden image of the region
+func (d DemoDataProvider) demoParameters() []demoParameter {
+	return []demoParameter{
+		{name: "/demo/app/db-host", paramType: ssmtypes.ParameterTypeString, value: "orders.cluster-demo." + d.region + ".rds.amazonaws.com", version: 3, modifiedDays: 41},
+		{name: "/demo/app/db-password", paramType: ssmtypes.ParameterTypeSecureString, value: "demo-not-a-secret", version: 7, modifiedDays: 12},
+		{name: "/demo/app/feature-flags", paramType: ssmtypes.ParameterTypeStringList, value: "checkout-v2,dark-mode", version: 15, modifiedDays: 2},
+		{name: "/demo/app/log-level", paramType: ssmtypes.ParameterTypeString, value: "info", version: 1, modifiedDays: 300},
+		{name: "/demo/golden-ami/ubuntu-22.04", paramType: ssmtypes.ParameterTypeString, value: d.amiID(1), version: 41, modifiedDays: 38},
+		{name: "/demo/billing/api-key", paramType: ssmtypes.ParameterTypeSecureString, value: "demo-not-a-key", version: 2, modifiedDays: 420},
+	}
+}
+
+// id derives a stable resource ID from the region and a name
+func (d DemoDataProvider) id(prefix, name string) string {
+	h := fnv.New64a()
+	h.Write([]byte(d.region + "/" + name))
+	return fmt.Sprintf("%s-%017x", prefix, h.Sum64())
+}
+
+func (d DemoDataProvider) amiID(i int) string {
+	return d.id("ami", demoAMIs[i].name)
+}
+
+func (d DemoDataProvider) snapshotID(i int) string {
+	return d.id("snap", "snapshot/"+demoAMIs[i].name)
+}
+
+func (d DemoDataProvider) groupID(name string) string {
+	return d.id("sg", name)
+}
+
+func (d DemoDataProvider) daysAgo(days int) time.Time {
+	return d.now.AddDate(0, 0, -days)
+}
+
+func (d DemoDataProvider) DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	var instances []ec2types.Instance
+	for i, inst := range demoInstances {
+		tags := map[string]string{"Name": inst.name, "Team": inst.team, "Environment": inst.env}
+		if !demoInstanceMatches(inst, tags, params.Filters) {
+			continue
+		}
+		instance := ec2types.Instance{
+			InstanceId:       aws.String(d.id("i", inst.name)),
+			ImageId:          aws.String(d.amiID(inst.ami)),
+			InstanceType:     ec2types.InstanceType(inst.instanceType),
+			State:            &ec2types.InstanceState{Name: inst.state},
+			LaunchTime:       aws.Time(d.daysAgo(inst.launchedDaysAgo)),
+			Placement:        &ec2types.Placement{AvailabilityZone: aws.String(d.region + string(rune('a'+i%3)))},
+			VpcId:            aws.String(d.id("vpc", "main")),
+			SubnetId:         aws.String(d.id("subnet", fmt.Sprintf("private-%d", i%3))),
+			PrivateIpAddress: aws.String(fmt.Sprintf("10.0.%d.%d", i%3, 10+i)),
+			PlatformDetails:  aws.String("Linux/UNIX"),
+			MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{
+				HttpTokens:   ec2types.HttpTokensStateRequired,
+				HttpEndpoint: ec2types.InstanceMetadataEndpointStateEnabled,
+			},
+		}
+		if strings.HasPrefix(demoAMIs[inst.ami].name, "windows") {
+			instance.PlatformDetails = aws.String("Windows")
+		}
+		if inst.imdsv1 {
+			instance.MetadataOptions.HttpTokens = ec2types.HttpTokensStateOptional
+		}
+		if inst.spot {
+			instance.InstanceLifecycle = ec2types.InstanceLifecycleTypeSpot
+		}
+		for _, g := range inst.groups {
+			instance.SecurityGroups = append(instance.SecurityGroups, ec2types.GroupIdentifier{GroupId: aws.String(d.groupID(g)), GroupName: aws.String(g)})
+		}
+		for key, value := range tags {
+			instance.Tags = append(instance.Tags, ec2types.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		instances = append(instances, instance)
+	}
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{{OwnerId: aws.String(demoAccountID), Instances: instances}},
+	}, nil
+}
+
+// demoInstanceMatches applies the instance-state-name and tag:Key filters of DescribeInstances
+func demoInstanceMatches(inst demoInstance, tags map[string]string, filters []ec2types.Filter) bool {
+	for _, f := range filters {
+		name := aws.ToString(f.Name)
+		switch {
+		case name == "instance-state-name":
+			if !slices.Contains(f.Values, string(inst.state)) {
+				return false
+			}
+		case strings.HasPrefix(name, "tag:"):
+			value, ok := tags[strings.TrimPrefix(name, "tag:")]
+			if !ok || !slices.ContainsFunc(f.Values, func(pattern string) bool {
+				matched, _ := path.Match(pattern, value)
+				return matched
+			}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (d DemoDataProvider) DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+	var ids []string
+	for _, f := range params.Filters {
+		if aws.ToString(f.Name) == "image-id" {
+			ids = append(ids, f.Values...)
+		}
+	}
+	ids = append(ids, params.ImageIds...)
+	// The images of another owner are the ones with an alias
+	selfOnly := slices.Contains(params.Owners, "self")
+
+	var images []ec2types.Image
+	for i, ami := range demoAMIs {
+		id := d.amiID(i)
+		if ami.deregistered || (len(ids) > 0 && !slices.Contains(ids, id)) || (selfOnly && ami.alias != "") {
+			continue
+		}
+		image := ec2types.Image{
+			ImageId:      aws.String(id),
+			Name:         aws.String(ami.name),
+			Description:  aws.String(ami.description),
+			OwnerId:      aws.String(demoAccountID),
+			CreationDate: aws.String(d.daysAgo(ami.ageDays).Format(time.RFC3339)),
+			Public:       aws.Bool(ami.public),
+			State:        ec2types.ImageStateAvailable,
+			BlockDeviceMappings: []ec2types.BlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				Ebs: &ec2types.EbsBlockDevice{
+					SnapshotId: aws.String(d.snapshotID(i)),
+					VolumeSize: aws.Int32(ami.volumeGiB),
+				},
+			}},
+		}
+		if ami.alias != "" {
+			image.ImageOwnerAlias = aws.String(ami.alias)
+			image.OwnerId = aws.String("137112412989")
+		}
+		if ami.deprecated {
+			image.DeprecationTime = aws.String(d.daysAgo(100).Format(time.RFC3339))
+		}
+		images = append(images, image)
+	}
+	return &ec2.DescribeImagesOutput{Images: images}, nil
+}
+
+func (d DemoDataProvider) DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	var groups []ec2types.SecurityGroup
+	for name, ports := range demoGroups {
+		group := ec2types.SecurityGroup{GroupId: aws.String(d.groupID(name)), GroupName: aws.String(name)}
+		for _, port := range ports {
+			group.IpPermissions = append(group.IpPermissions, ec2types.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(port),
+				ToPort:     aws.Int32(port),
+				IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			})
+		}
+		groups = append(groups, group)
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: groups}, nil
+}
+
+// DescribeSnapshots serves the snapshots of the AMIs owned by the demo account,
+// filtered by ID
+func (d DemoDataProvider) DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+	var ids []string
+	for _, f := range params.Filters {
+		if aws.ToString(f.Name) == "snapshot-id" {
+			ids = append(ids, f.Values...)
+		}
+	}
+	ids = append(ids, params.SnapshotIds...)
+
+	var snapshots []ec2types.Snapshot
+	for i, ami := range demoAMIs {
+		id := d.snapshotID(i)
+		if ami.alias != "" || ami.deregistered || (len(ids) > 0 && !slices.Contains(ids, id)) {
+			continue
+		}
+		snapshot := ec2types.Snapshot{
+			SnapshotId:  aws.String(id),
+			OwnerId:     aws.String(demoAccountID),
+			VolumeSize:  aws.Int32(ami.volumeGiB),
+			StartTime:   aws.Time(d.daysAgo(ami.ageDays)),
+			State:       ec2types.SnapshotStateCompleted,
+			StorageTier: ec2types.StorageTierStandard,
+		}
+		if ami.archived {
+			snapshot.StorageTier = ec2types.StorageTierArchive
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return &ec2.DescribeSnapshotsOutput{Snapshots: snapshots}, nil
+}
+
+func (d DemoDataProvider) DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	regions := make([]ec2types.Region, len(demoRegions))
+	for i, name := range demoRegions {
+		regions[i] = ec2types.Region{RegionName: aws.String(name), OptInStatus: aws.String("opt-in-not-required")}
+	}
+	return &ec2.DescribeRegionsOutput{Regions: regions}, nil
+}
+
+func (d DemoDataProvider) DescribeParameters(ctx context.Context, params *ssm.DescribeParametersInput, optFns ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	var out []ssmtypes.ParameterMetadata
+	for _, p := range d.demoParameters() {
+		if !demoParameterMatches(p, params.ParameterFilters) {
+			continue
+		}
+		out = append(out, ssmtypes.ParameterMetadata{
+			Name:             aws.String(p.name),
+			Type:             p.paramType,
+			Version:          p.version,
+			Tier:             ssmtypes.ParameterTierStandard,
+			LastModifiedDate: aws.Time(d.daysAgo(p.modifiedDays)),
+			LastModifiedUser: aws.String("arn:aws:iam::" + demoAccountID + ":user/deploy"),
+		})
+	}
+	return &ssm.DescribeParametersOutput{Parameters: out}, nil
+}
+
+func (d DemoDataProvider) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	prefix := strings.TrimSuffix(aws.ToString(params.Path), "/") + "/"
+	var out []ssmtypes.Parameter
+	for _, p := range d.demoParameters() {
+		rest, ok := strings.CutPrefix(p.name, prefix)
+		if !ok || (!aws.ToBool(params.Recursive) && strings.Contains(rest, "/")) || !demoParameterMatches(p, params.ParameterFilters) {
+			continue
+		}
+		out = append(out, ssmtypes.Parameter{
+			Name:             aws.String(p.name),
+			Type:             p.paramType,
+			Value:            aws.String(p.value),
+			Version:          p.version,
+			LastModifiedDate: aws.Time(d.daysAgo(p.modifiedDays)),
+		})
+	}
+	return &ssm.GetParametersByPathOutput{Parameters: out}, nil
+}
+
+// demoParameterMatches applies the Name and Type filters; the parameters have no tags nor KMS keys
+func demoParameterMatches(p demoParameter, filters []ssmtypes.ParameterStringFilter) bool {
+	for _, f := range filters {
+		switch aws.ToString(f.Key) {
+		case "Name":
+			if !slices.ContainsFunc(f.Values, func(v string) bool {
+				if aws.ToString(f.Option) == "BeginsWith" {
+					return strings.HasPrefix(p.name, v)
+				}
+				return p.name == v
+			}) {
+				return false
+			}
+		case "Type":
+			if !slices.Contains(f.Values, string(p.paramType)) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// demoSecret is a secret of the demo account
+type demoSecret struct {
+	name         string
+	description  string
+	team         string
+	rotation     bool
+	changedDays  int
+	accessedDays int
+}
+
+var demoSecrets = []demoSecret{
+	{name: "/demo/app/db-credentials", description: "Orders database master user", team: "storefront", rotation: true, changedDays: 25, accessedDays: 0},
+	{name: "/demo/app/stripe-api-key", description: "Payment provider API key", team: "storefront", changedDays: 180, accessedDays: 1},
+	{name: "/demo/billing/sftp-password", description: "Legacy billing export", team: "finance", changedDays: 700, accessedDays: 95},
+}
+
+// ListSecrets applies the name prefix filter; the other filters are not served
+func (d DemoDataProvider) ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	var out []smtypes.SecretListEntry
+	for _, s := range demoSecrets {
+		if !demoSecretMatches(s, params.Filters) {
+			continue
+		}
+		out = append(out, smtypes.SecretListEntry{
+			Name:             aws.String(s.name),
+			ARN:              aws.String("arn:aws:secretsmanager:" + d.region + ":" + demoAccountID + ":secret:" + s.name + "-AbCdEf"),
+			Description:      aws.String(s.description),
+			RotationEnabled:  aws.Bool(s.rotation),
+			LastChangedDate:  aws.Time(d.daysAgo(s.changedDays)),
+			LastAccessedDate: aws.Time(d.daysAgo(s.accessedDays).Truncate(24 * time.Hour)),
+			Tags:             []smtypes.Tag{{Key: aws.String("team"), Value: aws.String(s.team)}},
+		})
+	}
+	return &secretsmanager.ListSecretsOutput{SecretList: out}, nil
+}
+
+// demoSecretMatches applies the name filters, a prefix match as in Secrets Manager
+func demoSecretMatches(s demoSecret, filters []smtypes.Filter) bool {
+	for _, f := range filters {
+		if f.Key != smtypes.FilterNameStringTypeName {
+			return false
+		}
+		if !slices.ContainsFunc(f.Values, func(v string) bool { return strings.HasPrefix(s.name, v) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d DemoDataProvider) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(demoAccountID),
+		Arn:     aws.String("arn:aws:sts::" + demoAccountID + ":assumed-role/DemoReadOnly/demo"),
+		UserId:  aws.String("AROADEMODEMODEMODEMO:demo"),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// NetworkInterfaceInfo is a network interface attached to an instance
+type NetworkInterfaceInfo struct {
+	ID               string   `json:"id"`
+	SubnetID         string   `json:"subnetId"`
+	VpcID            string   `json:"vpcId"`
+	PrivateIP        string   `json:"privateIp"`
+	PublicIP         string   `json:"publicIp"`
+	SecurityGroupIDs []string `json:"securityGroupIds"`
+}
+
+// VolumeInfo is an EBS volume attached to an instance
+type VolumeInfo struct {
+	DeviceName          string `json:"deviceName"`
+	VolumeID            string `json:"volumeId"`
+	SizeGiB             int32  `json:"sizeGiB"`
+	VolumeType          string `json:"volumeType"`
+	Encrypted           bool   `json:"encrypted"`
+	DeleteOnTermination bool   `json:"deleteOnTermination"`
+}
+
+// SecurityGroupRef identifies a security group
+type SecurityGroupRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// InstanceDetails is the full view of a single instance for the detail pane
+type InstanceDetails struct {
+	Instance          EC2Instance            `json:"instance"`
+	NetworkInterfaces []NetworkInterfaceInfo `json:"networkInterfaces"`
+	Volumes           []VolumeInfo           `json:"volumes"`
+	SecurityGroups    []SecurityGroupRef     `json:"securityGroups"`
+	// IAMInstanceProfile is the ARN of the instance profile, empty if none
+	IAMInstanceProfile string `json:"iamInstanceProfile"`
+	// HasUserData tells whether user data is set; its content is never returned
+	HasUserData bool `json:"hasUserData"`
+}
+
+// GetInstanceDetails describes one instance in full. region is the Region of the
+// scanned instance; "" is the profile's region.
+func (a *App) GetInstanceDetails(profile string, region string, instanceID string) (*InstanceDetails, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if region != "" {
+		cfg.Region = region
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	out, err := ec2Client.DescribeInstances(a.ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance %s: %w", instanceID, err)
+	}
+	if len(out.Reservations) == 0 || len(out.Reservations[0].Instances) == 0 {
+		return nil, fmt.Errorf("instance %s not found", instanceID)
+	}
+	res := out.Reservations[0]
+	inst := res.Instances[0]
+
+	details := &InstanceDetails{
+		Instance:          toEC2Instance(inst, cfg.Region, aws.ToString(res.OwnerId)),
+		NetworkInterfaces: []NetworkInterfaceInfo{},
+		Volumes:           []VolumeInfo{},
+		SecurityGroups:    []SecurityGroupRef{},
+	}
+	if inst.IamInstanceProfile != nil {
+		details.IAMInstanceProfile = aws.ToString(inst.IamInstanceProfile.Arn)
+	}
+
+	// 1. AMI details, as in the scan
+	if img, err := describeAMI(a.ctx, ec2Client, details.Instance.AMI); err == nil {
+		if img != nil {
+			applyImageDetails(&details.Instance, *img)
+		} else if details.Instance.AMI != "" {
+			details.Instance.AMIStatus = AMIStatusDeregistered
+		}
+	}
+
+	// 2. Network
+	for _, eni := range inst.NetworkInterfaces {
+		info := NetworkInterfaceInfo{
+			ID:               aws.ToString(eni.NetworkInterfaceId),
+			SubnetID:         aws.ToString(eni.SubnetId),
+			VpcID:            aws.ToString(eni.VpcId),
+			PrivateIP:        aws.ToString(eni.PrivateIpAddress),
+			SecurityGroupIDs: []string{},
+		}
+		if eni.Association != nil {
+			info.PublicIP = aws.ToString(eni.Association.PublicIp)
+		}
+		for _, g := range eni.Groups {
+			info.SecurityGroupIDs = append(info.SecurityGroupIDs, aws.ToString(g.GroupId))
+		}
+		details.NetworkInterfaces = append(details.NetworkInterfaces, info)
+	}
+	details.SecurityGroups = details.Instance.SecurityGroups
+	audited := []EC2Instance{details.Instance}
+	if err := auditSecurityGroups(a.ctx, ec2Client, audited); err != nil {
+		slog.Warn("Unable to audit security groups", "err", err)
+	}
+	details.Instance = audited[0]
+
+	// 3. Volumes: the mapping only holds IDs, sizes and types need DescribeVolumes
+	var volumeIDs []string
+	for _, bdm := range inst.BlockDeviceMappings {
+		if bdm.Ebs != nil && bdm.Ebs.VolumeId != nil {
+			volumeIDs = append(volumeIDs, *bdm.Ebs.VolumeId)
+		}
+	}
+	volumes := make(map[string]ec2types.Volume)
+	if len(volumeIDs) > 0 {
+		volOut, err := ec2Client.DescribeVolumes(a.ctx, &ec2.DescribeVolumesInput{VolumeIds: volumeIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe volumes of %s: %w", instanceID, err)
+		}
+		for _, v := range volOut.Volumes {
+			volumes[aws.ToString(v.VolumeId)] = v
+		}
+	}
+	for _, bdm := range inst.BlockDeviceMappings {
+		if bdm.Ebs == nil {
+			continue
+		}
+		info := VolumeInfo{
+			DeviceName:          aws.ToString(bdm.DeviceName),
+			VolumeID:            aws.ToString(bdm.Ebs.VolumeId),
+			DeleteOnTermination: aws.ToBool(bdm.Ebs.DeleteOnTermination),
+		}
+		if v, ok := volumes[info.VolumeID]; ok {
+			info.SizeGiB = aws.ToInt32(v.Size)
+			info.VolumeType = string(v.VolumeType)
+			info.Encrypted = aws.ToBool(v.Encrypted)
+		}
+		details.Volumes = append(details.Volumes, info)
+	}
+
+	// 4. User data presence
+	attr, err := ec2Client.DescribeInstanceAttribute(a.ctx, &ec2.DescribeInstanceAttributeInput{
+		InstanceId: aws.String(instanceID),
+		Attribute:  ec2types.InstanceAttributeNameUserData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user data attribute of %s: %w", instanceID, err)
+	}
+	details.HasUserData = attr.UserData != nil && aws.ToString(attr.UserData.Value) != ""
+
+	return details, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EC2Filter narrows the DescribeInstances lookup.
+// Only the fields that are set become filters.
+type EC2Filter struct {
+	// Tags are "Key=Value" pairs; the value accepts the * and ? wildcards and
+	// a bare "Key" only requires the tag to exist. Values given for the same
+	// key are ORed, different keys are ANDed.
+	Tags []string `json:"tags"`
+	// States keeps only the instances in these states, e.g. running and stopped
+	States []string `json:"states"`
+}
+
+// validInstanceStates are the states accepted by the instance-state-name filter
+var validInstanceStates = func() map[string]bool {
+	states := make(map[string]bool)
+	for _, s := range ec2types.InstanceStateName("").Values() {
+		states[string(s)] = true
+	}
+	return states
+}()
+
+// buildInstanceFilters converts an EC2Filter into DescribeInstances filters
+func buildInstanceFilters(filter EC2Filter) ([]ec2types.Filter, error) {
+	var filters []ec2types.Filter
+
+	if len(filter.States) > 0 {
+		for _, s := range filter.States {
+			if !validInstanceStates[s] {
+				return nil, fmt.Errorf("invalid instance state %q: must be one of pending, running, shutting-down, terminated, stopping, stopped", s)
+			}
+		}
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String("instance-state-name"),
+			Values: filter.States,
+		})
+	}
+
+	values := make(map[string][]string)
+	for _, t := range filter.Tags {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(t), "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q: expected Key=Value or Key", t)
+		}
+		if !hasValue {
+			// Any value: the tag only has to exist
+			value = "*"
+		}
+		values[key] = append(values[key], strings.TrimSpace(value))
+	}
+
+	// Sorted so the request is the same from one scan to the next
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		filters = append(filters, ec2types.Filter{Name: aws.String("tag:" + k), Values: values[k]})
+	}
+	return filters, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// describeServicesBatch is the most services DescribeServices accepts per call
+const describeServicesBatch = 10
+
+// dockerHubRegistry is the registry of images named without one, e.g. nginx:1.25
+const dockerHubRegistry = "docker.io"
+
+// containerImage is an image reference split into registry, repository and tag
+type containerImage struct {
+	Registry   string
+	Repository string
+	// Tag is "latest" when the reference has neither tag nor digest
+	Tag    string
+	Digest string
+}
+
+// parseImage splits an image reference such as
+// 123456789012.dkr.ecr.eu-west-1.amazonaws.com/app:1.2 or nginx@sha256:...
+func parseImage(ref string) containerImage {
+	var img containerImage
+	name := ref
+	if i := strings.Index(name, "@"); i >= 0 {
+		img.Digest = name[i+1:]
+		name = name[:i]
+	}
+	// A tag is a ":" after the last "/", otherwise the ":" belongs to a registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		img.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if img.Tag == "" && img.Digest == "" {
+		img.Tag = "latest"
+	}
+
+	img.Registry = dockerHubRegistry
+	if first, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		img.Registry = first
+		name = rest
+	}
+	img.Repository = name
+	return img
+}
+
+// imageFinding flags latest tags and registries missing from approved (when set)
+func imageFinding(img containerImage, approved []string) (bool, string) {
+	var findings []string
+	if img.Tag == "latest" && img.Digest == "" {
+		findings = append(findings, "uses the latest tag")
+	}
+	if len(approved) > 0 {
+		ok := false
+		for _, r := range approved {
+			if strings.EqualFold(strings.TrimSuffix(r, "/"), img.Registry) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			findings = append(findings, fmt.Sprintf("registry %s is not approved", img.Registry))
+		}
+	}
+	return len(findings) > 0, strings.Join(findings, "; ")
+}
+
+// scanECS lists the containers of every ECS service with the image they run,
+// flagging latest tags and unapproved registries
+func (a *App) scanECS(ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error) {
+	client := ecs.NewFromConfig(cfg)
+	resources := []ServiceResource{}
+	taskDefs := make(map[string]*ecs.DescribeTaskDefinitionOutput)
+
+	clusters := ecs.NewListClustersPaginator(client, &ecs.ListClustersInput{})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ecs clusters: %w", err)
+		}
+		for _, clusterARN := range page.ClusterArns {
+			var serviceARNs []string
+			services := ecs.NewListServicesPaginator(client, &ecs.ListServicesInput{Cluster: aws.String(clusterARN)})
+			for services.HasMorePages() {
+				sp, err := services.NextPage(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list ecs services of %s: %w", clusterARN, err)
+				}
+				serviceARNs = append(serviceARNs, sp.ServiceArns...)
+			}
+
+			for start := 0; start < len(serviceARNs); start += describeServicesBatch {
+				end := min(start+describeServicesBatch, len(serviceARNs))
+				out, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+					Cluster:  aws.String(clusterARN),
+					Services: serviceARNs[start:end],
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to describe ecs services of %s: %w", clusterARN, err)
+				}
+				for _, svc := range out.Services {
+					tdARN := aws.ToString(svc.TaskDefinition)
+					td, ok := taskDefs[tdARN]
+					if !ok {
+						td, err = client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(tdARN)})
+						if err != nil {
+							return nil, fmt.Errorf("failed to describe task definition %s: %w", tdARN, err)
+						}
+						taskDefs[tdARN] = td
+					}
+					if td.TaskDefinition == nil {
+						continue
+					}
+
+					for _, c := range td.TaskDefinition.ContainerDefinitions {
+						image := aws.ToString(c.Image)
+						img := parseImage(image)
+						r := ServiceResource{
+							Service: "ecs",
+							Type:    "container",
+							ID:      aws.ToString(svc.ServiceName) + "/" + aws.ToString(c.Name),
+							ARN:     aws.ToString(svc.ServiceArn),
+							Region:  cfg.Region,
+							Version: img.Tag,
+							Status:  aws.ToString(svc.Status),
+							Details: map[string]string{
+								"cluster":        clusterName(clusterARN),
+								"taskDefinition": tdARN,
+								"image":          image,
+							},
+						}
+						if img.Digest != "" {
+							r.Details["digest"] = img.Digest
+						}
+						r.Flagged, r.Finding = imageFinding(img, req.ApprovedRegistries)
+						resources = append(resources, r)
+					}
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// clusterName returns the name part of a cluster ARN
+func clusterName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// eksService is the key of the EKS resources in the services section
+const eksService = "eks"
+
+// eksReleaseParameters are the public SSM parameters holding the recommended release
+// of each node group AMI type, formatted with the Kubernetes version.
+// CUSTOM node groups run their own AMI and have no recommended release.
+var eksReleaseParameters = map[string]string{
+	"AL2_x86_64":             "/aws/service/eks/optimized-ami/%s/amazon-linux-2/recommended/release_version",
+	"AL2_x86_64_GPU":         "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/release_version",
+	"AL2_ARM_64":             "/aws/service/eks/optimized-ami/%s/amazon-linux-2-arm64/recommended/release_version",
+	"AL2023_x86_64_STANDARD": "/aws/service/eks/optimized-ami/%s/amazon-linux-2023/x86_64/standard/recommended/release_version",
+	"AL2023_ARM_64_STANDARD": "/aws/service/eks/optimized-ami/%s/amazon-linux-2023/arm64/standard/recommended/release_version",
+	"AL2023_x86_64_NVIDIA":   "/aws/service/eks/optimized-ami/%s/amazon-linux-2023/x86_64/nvidia/recommended/release_version",
+	"BOTTLEROCKET_x86_64":    "/aws/service/bottlerocket/aws-k8s-%s/x86_64/latest/image_version",
+	"BOTTLEROCKET_ARM_64":    "/aws/service/bottlerocket/aws-k8s-%s/arm64/latest/image_version",
+}
+
+// eksNodegroup is a managed node group with the Kubernetes version of its cluster
+type eksNodegroup struct {
+	cluster        string
+	clusterVersion string
+	name           string
+	arn            string
+	version        string
+	releaseVersion string
+	amiType        string
+	status         string
+}
+
+// scanEKS reports the release of every managed node group and flags those behind
+// the recommended release of their AMI type, or behind their control plane
+func (a *App) scanEKS(ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error) {
+	nodegroups, err := eksNodegroups(ctx, eks.NewFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	// One lookup per AMI type and Kubernetes version
+	seen := make(map[string]bool)
+	var names []string
+	for _, ng := range nodegroups {
+		format, ok := eksReleaseParameters[ng.amiType]
+		if !ok {
+			continue
+		}
+		name := fmt.Sprintf(format, ng.version)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	recommended, err := getParameters(ctx, ssm.NewFromConfig(cfg), names, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []ServiceResource{}
+	for _, ng := range nodegroups {
+		r := ServiceResource{
+			Service: eksService,
+			Type:    "nodegroup",
+			ID:      ng.cluster + "/" + ng.name,
+			ARN:     ng.arn,
+			Region:  cfg.Region,
+			Version: ng.releaseVersion,
+			Status:  ng.status,
+			Details: map[string]string{
+				"amiType":           ng.amiType,
+				"kubernetesVersion": ng.version,
+				"clusterVersion":    ng.clusterVersion,
+			},
+		}
+		if format, ok := eksReleaseParameters[ng.amiType]; ok {
+			if p, ok := recommended[fmt.Sprintf(format, ng.version)]; ok {
+				latest := aws.ToString(p.Value)
+				r.Details["recommendedRelease"] = latest
+				if latest != ng.releaseVersion {
+					r.Flagged = true
+					r.Finding = fmt.Sprintf("release %s is behind the recommended %s", ng.releaseVersion, latest)
+				}
+			}
+		}
+		if ng.clusterVersion != "" && ng.version != ng.clusterVersion {
+			r.Flagged = true
+			finding := fmt.Sprintf("Kubernetes %s is behind the control plane %s", ng.version, ng.clusterVersion)
+			if r.Finding != "" {
+				finding = r.Finding + "; " + finding
+			}
+			r.Finding = finding
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// eksNodegroups describes every managed node group of every cluster in the region of client
+func eksNodegroups(ctx context.Context, client *eks.Client) ([]eksNodegroup, error) {
+	var clusters []string
+	pager := eks.NewListClustersPaginator(client, &eks.ListClustersInput{})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list eks clusters: %w", err)
+		}
+		clusters = append(clusters, page.Clusters...)
+	}
+
+	var nodegroups []eksNodegroup
+	for _, cluster := range clusters {
+		desc, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(cluster)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe eks cluster %s: %w", cluster, err)
+		}
+		clusterVersion := aws.ToString(desc.Cluster.Version)
+
+		pager := eks.NewListNodegroupsPaginator(client, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list node groups of %s: %w", cluster, err)
+			}
+			for _, name := range page.Nodegroups {
+				out, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: aws.String(cluster), NodegroupName: aws.String(name)})
+				if err != nil {
+					return nil, fmt.Errorf("failed to describe node group %s/%s: %w", cluster, name, err)
+				}
+				n := out.Nodegroup
+				nodegroups = append(nodegroups, eksNodegroup{
+					cluster:        cluster,
+					clusterVersion: clusterVersion,
+					name:           aws.ToString(n.NodegroupName),
+					arn:            aws.ToString(n.NodegroupArn),
+					version:        aws.ToString(n.Version),
+					releaseVersion: aws.ToString(n.ReleaseVersion),
+					amiType:        string(n.AmiType),
+					status:         string(n.Status),
+				})
+			}
+		}
+	}
+	return nodegroups, nil
+}
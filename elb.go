@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// elbService is the service key of the load balancer scan
+const elbService = "elb"
+
+// scanELB lists the target groups of the ALBs and NLBs with their health,
+// flagging target groups with unhealthy targets
+func (a *App) scanELB(ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error) {
+	client := elb.NewFromConfig(cfg)
+
+	lbs := make(map[string]elbtypes.LoadBalancer)
+	lbPager := elb.NewDescribeLoadBalancersPaginator(client, &elb.DescribeLoadBalancersInput{})
+	for lbPager.HasMorePages() {
+		page, err := lbPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancers: %w", err)
+		}
+		for _, lb := range page.LoadBalancers {
+			lbs[aws.ToString(lb.LoadBalancerArn)] = lb
+		}
+	}
+
+	resources := []ServiceResource{}
+	tgPager := elb.NewDescribeTargetGroupsPaginator(client, &elb.DescribeTargetGroupsInput{})
+	for tgPager.HasMorePages() {
+		page, err := tgPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe target groups: %w", err)
+		}
+		for _, tg := range page.TargetGroups {
+			health, err := client.DescribeTargetHealth(ctx, &elb.DescribeTargetHealthInput{TargetGroupArn: tg.TargetGroupArn})
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe target health of %s: %w", aws.ToString(tg.TargetGroupName), err)
+			}
+
+			var lbNames, lbTypes []string
+			for _, arn := range tg.LoadBalancerArns {
+				if lb, ok := lbs[arn]; ok {
+					lbNames = append(lbNames, aws.ToString(lb.LoadBalancerName))
+					lbTypes = append(lbTypes, string(lb.Type))
+				}
+			}
+
+			r := ServiceResource{
+				Service: elbService,
+				Type:    "target-group",
+				ID:      aws.ToString(tg.TargetGroupName),
+				ARN:     aws.ToString(tg.TargetGroupArn),
+				Region:  cfg.Region,
+				Version: strings.Join(lbTypes, ","),
+				Details: map[string]string{
+					"loadBalancers": strings.Join(lbNames, ","),
+					"protocol":      string(tg.Protocol),
+					"targetType":    string(tg.TargetType),
+				},
+			}
+			if tg.Port != nil {
+				r.Details["port"] = strconv.Itoa(int(*tg.Port))
+			}
+
+			unhealthy := 0
+			for _, t := range health.TargetHealthDescriptions {
+				if t.TargetHealth != nil && t.TargetHealth.State == elbtypes.TargetHealthStateEnumUnhealthy {
+					unhealthy++
+				}
+				if tg.TargetType == elbtypes.TargetTypeEnumInstance && t.Target != nil {
+					r.Targets = append(r.Targets, aws.ToString(t.Target.Id))
+				}
+			}
+			total := len(health.TargetHealthDescriptions)
+			r.Status = fmt.Sprintf("%d/%d healthy", total-unhealthy, total)
+			if unhealthy > 0 {
+				r.Flagged = true
+				r.Finding = fmt.Sprintf("%d unhealthy target(s)", unhealthy)
+			}
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// attachLoadBalancers sets the load balancers each instance is registered behind,
+// from the target groups found by scanELB
+func attachLoadBalancers(instances []EC2Instance, targetGroups []ServiceResource) {
+	byInstance := make(map[string]map[string]bool)
+	for _, tg := range targetGroups {
+		lbNames := strings.Split(tg.Details["loadBalancers"], ",")
+		for _, id := range tg.Targets {
+			for _, name := range lbNames {
+				if name == "" {
+					continue
+				}
+				if byInstance[id] == nil {
+					byInstance[id] = make(map[string]bool)
+				}
+				byInstance[id][name] = true
+			}
+		}
+	}
+
+	for i := range instances {
+		names := byInstance[instances[i].InstanceID]
+		if len(names) == 0 {
+			continue
+		}
+		instances[i].LoadBalancers = make([]string, 0, len(names))
+		for name := range names {
+			instances[i].LoadBalancers = append(instances[i].LoadBalancers, name)
+		}
+		sort.Strings(instances[i].LoadBalancers)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultEndpointProbeTimeout bounds the reachability check of a custom endpoint
+// when no operation timeout is configured
+const defaultEndpointProbeTimeout = 3 * time.Second
+
+// PingEndpoint checks that the profile's endpoint_url answers before talking to STS.
+// Profiles without a custom endpoint use real AWS and are never probed.
+func (a *App) PingEndpoint(profile string) error {
+	endpointURL := a.getEndpointFromConfig(profile)
+	if endpointURL == "" {
+		return nil
+	}
+	return a.probeEndpoint(a.ctx, endpointURL)
+}
+
+// serviceEndpoints reads the endpoint_url overrides of the [services] section the
+// profile references, keyed by service (ec2, secrets_manager...):
+//
+//	[services local]
+//	ec2 =
+//	  endpoint_url = http://localhost:4566
+func (a *App) serviceEndpoints(profile string) map[string]string {
+	name := a.getProfileValue(profile, "services")
+	if name == "" {
+		return nil
+	}
+	cfg, err := a.loadAWSConfigFile()
+	if err != nil {
+		return nil
+	}
+
+	endpoints := make(map[string]string)
+	for _, key := range cfg.Section("services " + name).Keys() {
+		for _, line := range strings.Split(key.Value(), "\n") {
+			k, v, ok := strings.Cut(line, "=")
+			if ok && strings.TrimSpace(k) == "endpoint_url" {
+				endpoints[key.Name()] = strings.TrimSpace(v)
+			}
+		}
+	}
+	return endpoints
+}
+
+// serviceConfigKey maps an SDK service ID ("Secrets Manager") to its services section key ("secrets_manager")
+func serviceConfigKey(serviceID string) string {
+	return strings.ReplaceAll(strings.ToLower(serviceID), " ", "_")
+}
+
+// endpointProbeTimeout returns the timeout of the GetCallerIdentity call the probe
+// guards, so an endpoint slow to answer gets as long as the call itself would
+func (a *App) endpointProbeTimeout() time.Duration {
+	if timeout := a.timeoutFor("GetCallerIdentity"); timeout > 0 {
+		return timeout
+	}
+	return defaultEndpointProbeTimeout
+}
+
+// probeEndpoint dials the endpoint host and makes sure it speaks HTTP
+func (a *App) probeEndpoint(ctx context.Context, endpointURL string) error {
+	u, err := url.Parse(endpointURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid endpoint_url %q", endpointURL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.endpointProbeTimeout())
+	defer cancel()
+
+	// 1. TCP: is anything listening at all?
+	host := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("endpoint %q not reachable: %w", endpointURL, err)
+	}
+	conn.Close()
+
+	// 2. HTTP: a stale port may be taken by something that isn't LocalStack/AWS
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint_url %q: %w", endpointURL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("endpoint %q reachable but not answering HTTP, check it is LocalStack/AWS: %w", endpointURL, err)
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ScanProgress is emitted as "scan:progress" while a scan paginates
+type ScanProgress struct {
+	RequestID string `json:"requestId"`
+	Region    string `json:"region"`
+	Phase     string `json:"phase"`
+	Pages     int    `json:"pages"`
+	Items     int    `json:"items"`
+}
+
+type requestIDKey struct{}
+
+// withRequestID tags ctx with the ID of the request it belongs to
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// emit sends an event to the frontend.
+// The Wails runtime aborts the process when the context has no frontend attached,
+// so events are dropped until startup has run.
+func (a *App) emit(name string, data ...interface{}) {
+	if a.ctx == nil || a.ctx.Value("events") == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, name, data...)
+}
+
+// reportProgress emits a scan:progress event for the request carried by ctx
+func (a *App) reportProgress(ctx context.Context, progress ScanProgress) {
+	progress.RequestID, _ = ctx.Value(requestIDKey{}).(string)
+	a.emit("scan:progress", progress)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// sensitivePorts are the TCP ports that should never be open to the whole internet
+var sensitivePorts = map[int32]string{
+	21:    "FTP",
+	22:    "SSH",
+	23:    "Telnet",
+	445:   "SMB",
+	1433:  "SQL Server",
+	1521:  "Oracle",
+	2375:  "Docker",
+	3306:  "MySQL",
+	3389:  "RDP",
+	5432:  "PostgreSQL",
+	5601:  "Kibana",
+	6379:  "Redis",
+	9200:  "Elasticsearch",
+	11211: "Memcached",
+	27017: "MongoDB",
+}
+
+// SecurityExposure is a sensitive port an instance exposes to the internet
+type SecurityExposure struct {
+	GroupID string `json:"groupId"`
+	Port    int32  `json:"port"`
+	Service string `json:"service"`
+	// CIDR is 0.0.0.0/0 or ::/0
+	CIDR string `json:"cidr"`
+}
+
+// describeSecurityGroupsBatchSize keeps the group-id filter within the API limits
+const describeSecurityGroupsBatchSize = 200
+
+// auditSecurityGroups flags, on each instance, the sensitive ports its security
+// groups open to 0.0.0.0/0 or ::/0
+func auditSecurityGroups(ctx context.Context, client ec2.DescribeSecurityGroupsAPIClient, instances []EC2Instance) error {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, inst := range instances {
+		for _, g := range inst.SecurityGroups {
+			if !seen[g.ID] {
+				seen[g.ID] = true
+				ids = append(ids, g.ID)
+			}
+		}
+	}
+
+	exposures := make(map[string][]SecurityExposure)
+	for start := 0; start < len(ids); start += describeSecurityGroupsBatchSize {
+		end := min(start+describeSecurityGroupsBatchSize, len(ids))
+		pager := ec2.NewDescribeSecurityGroupsPaginator(client, &ec2.DescribeSecurityGroupsInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("group-id"), Values: ids[start:end]},
+			},
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to describe security groups: %w", err)
+			}
+			for _, sg := range page.SecurityGroups {
+				exposures[aws.ToString(sg.GroupId)] = groupExposures(sg)
+			}
+		}
+	}
+
+	for i := range instances {
+		instances[i].Exposures = []SecurityExposure{}
+		for _, g := range instances[i].SecurityGroups {
+			instances[i].Exposures = append(instances[i].Exposures, exposures[g.ID]...)
+		}
+	}
+	return nil
+}
+
+// groupExposures returns the sensitive ports a security group opens to the internet
+func groupExposures(sg ec2types.SecurityGroup) []SecurityExposure {
+	var found []SecurityExposure
+	for _, perm := range sg.IpPermissions {
+		var cidrs []string
+		for _, r := range perm.IpRanges {
+			if aws.ToString(r.CidrIp) == "0.0.0.0/0" {
+				cidrs = append(cidrs, "0.0.0.0/0")
+			}
+		}
+		for _, r := range perm.Ipv6Ranges {
+			if aws.ToString(r.CidrIpv6) == "::/0" {
+				cidrs = append(cidrs, "::/0")
+			}
+		}
+		if len(cidrs) == 0 {
+			continue
+		}
+
+		for port, service := range sensitivePorts {
+			if !permissionCoversPort(perm, port) {
+				continue
+			}
+			for _, cidr := range cidrs {
+				found = append(found, SecurityExposure{
+					GroupID: aws.ToString(sg.GroupId),
+					Port:    port,
+					Service: service,
+					CIDR:    cidr,
+				})
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Port < found[j].Port })
+	return found
+}
+
+// permissionCoversPort reports whether an ingress rule allows TCP traffic to port
+func permissionCoversPort(perm ec2types.IpPermission, port int32) bool {
+	switch aws.ToString(perm.IpProtocol) {
+	case "-1":
+		// All traffic
+		return true
+	case "tcp", "6":
+		return aws.ToInt32(perm.FromPort) <= port && port <= aws.ToInt32(perm.ToPort)
+	}
+	return false
+}
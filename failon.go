@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"goCheckAmi/policy"
+)
+
+// exitFindings is the exit code of the CLI when instances match a --fail-on check
+const exitFindings = 2
+
+// failOnChecks are the findings --fail-on accepts, matched against each instance.
+// public-ami is about the AMIs the scanned account shares publicly: a public AMI of
+// another owner, such as an official Amazon Linux image, is not a finding.
+var failOnChecks = map[string]func(EC2Instance) bool{
+	"stale-ami":        func(i EC2Instance) bool { return i.AMIStale },
+	"deprecated-ami":   func(i EC2Instance) bool { return i.AMIStatus == AMIStatusDeprecated },
+	"deregistered-ami": func(i EC2Instance) bool { return i.AMIStatus == AMIStatusDeregistered },
+	"public-ami":       func(i EC2Instance) bool { return i.AMIPublic && i.AMIOwner == i.AccountID },
+	"non-golden-ami":   func(i EC2Instance) bool { return i.NonCompliant },
+	"imdsv1":           func(i EC2Instance) bool { return i.IMDSv1Allowed },
+}
+
+// validateFailOn checks the --fail-on values before scanning
+func validateFailOn(checks []string) error {
+	for _, c := range checks {
+		if _, ok := failOnChecks[c]; !ok {
+			return fmt.Errorf("unknown --fail-on check %q: must be one of %s", c, strings.Join(slices.Sorted(maps.Keys(failOnChecks)), ", "))
+		}
+	}
+	return nil
+}
+
+// failOnFinding lists the instances matching one --fail-on check, or the
+// instances of the fail results of a policy rule ("" when not about an instance)
+type failOnFinding struct {
+	check     string
+	instances []string
+}
+
+// failOnFindings returns the checks matched by at least one instance, in the order given
+func failOnFindings(result *AWSResult, checks []string) []failOnFinding {
+	var findings []failOnFinding
+	for _, c := range checks {
+		match := failOnChecks[c]
+		f := failOnFinding{check: c}
+		for _, inst := range result.Instances {
+			if match(inst) {
+				f.instances = append(f.instances, inst.InstanceID)
+			}
+		}
+		if len(f.instances) > 0 {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// policyFindings turns the fail results of the policy into findings, one per rule
+func policyFindings(result *AWSResult) []failOnFinding {
+	var findings []failOnFinding
+	byRule := make(map[string]int)
+	for _, r := range result.PolicyResults {
+		if r.Severity != policy.SeverityFail {
+			continue
+		}
+		i, ok := byRule[r.Rule]
+		if !ok {
+			i = len(findings)
+			byRule[r.Rule] = i
+			findings = append(findings, failOnFinding{check: "policy " + r.Rule})
+		}
+		findings[i].instances = append(findings[i].instances, r.InstanceID)
+	}
+	return findings
+}
+
+// findingsError fails the CLI with exitFindings when --fail-on checks match
+type findingsError struct {
+	findings []failOnFinding
+}
+
+func (e *findingsError) Error() string {
+	parts := make([]string, len(e.findings))
+	for i, f := range e.findings {
+		parts[i] = fmt.Sprintf("%s: %d finding(s)", f.check, len(f.instances))
+		// Rego violations may not be about an instance
+		if ids := slices.DeleteFunc(slices.Clone(f.instances), func(id string) bool { return id == "" }); len(ids) > 0 {
+			parts[i] += " (" + strings.Join(ids, ", ") + ")"
+		}
+	}
+	return "policy check failed: " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// favoritesBucket holds the pinned instances and parameters, keyed by kind and ID
+var favoritesBucket = []byte("favorites")
+
+// Favorite kinds
+const (
+	FavoriteInstance  = "instance"
+	FavoriteParameter = "parameter"
+)
+
+// Favorite is a pinned instance (by instance ID) or parameter (by name),
+// listed first in the results of every scan
+type Favorite struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+func (f Favorite) key() []byte {
+	return []byte(f.Kind + "\x00" + f.ID)
+}
+
+// PinFavorite pins an instance or parameter to the top of the results
+func (a *App) PinFavorite(fav Favorite) error {
+	if fav.Kind != FavoriteInstance && fav.Kind != FavoriteParameter {
+		return fmt.Errorf("unknown favorite kind %q: must be %s or %s", fav.Kind, FavoriteInstance, FavoriteParameter)
+	}
+	if fav.ID == "" {
+		return fmt.Errorf("favorite %s has no ID", fav.Kind)
+	}
+	db, err := a.db()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(fav)
+	if err != nil {
+		return fmt.Errorf("failed to encode favorite: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(favoritesBucket).Put(fav.key(), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pin %s %s: %w", fav.Kind, fav.ID, err)
+	}
+	return nil
+}
+
+// UnpinFavorite removes a pinned instance or parameter
+func (a *App) UnpinFavorite(fav Favorite) error {
+	db, err := a.db()
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(favoritesBucket).Delete(fav.key())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unpin %s %s: %w", fav.Kind, fav.ID, err)
+	}
+	return nil
+}
+
+// ListFavorites returns the pinned instances and parameters
+func (a *App) ListFavorites() ([]Favorite, error) {
+	db, err := a.db()
+	if err != nil {
+		return nil, err
+	}
+	favorites := []Favorite{}
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(favoritesBucket).ForEach(func(k, v []byte) error {
+			var f Favorite
+			if err := json.Unmarshal(v, &f); err != nil {
+				slog.Warn("Unable to decode favorite", "key", string(k), "err", err)
+				return nil
+			}
+			favorites = append(favorites, f)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list favorites: %w", err)
+	}
+	return favorites, nil
+}
+
+// pinFavorites marks the pinned instances and parameters of the result and moves
+// them first, keeping the order of the rest. Failures are logged, the result is still valid.
+func (a *App) pinFavorites(result *AWSResult) {
+	favorites, err := a.ListFavorites()
+	if err != nil {
+		slog.Warn("Unable to load favorites", "err", err)
+		return
+	}
+	pinned := make(map[Favorite]bool, len(favorites))
+	for _, f := range favorites {
+		pinned[f] = true
+	}
+
+	for i := range result.Instances {
+		result.Instances[i].Pinned = pinned[Favorite{Kind: FavoriteInstance, ID: result.Instances[i].InstanceID}]
+	}
+	sort.SliceStable(result.Instances, func(i, j int) bool {
+		return result.Instances[i].Pinned && !result.Instances[j].Pinned
+	})
+	for i := range result.Parameters {
+		result.Parameters[i].Pinned = pinned[Favorite{Kind: FavoriteParameter, ID: result.Parameters[i].Name}]
+	}
+	sort.SliceStable(result.Parameters, func(i, j int) bool {
+		return result.Parameters[i].Pinned && !result.Parameters[j].Pinned
+	})
+}
@@ -1,7 +1,184 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
+import {time} from '../models';
 
-export function ListProfiles():Promise<Array<string>>;
+export function AuditAMIPermissions(arg1:string,arg2:Array<string>):Promise<main.AMIPermissionAudit>;
+
+export function AuditLaunchTemplates(arg1:string):Promise<main.LaunchTemplateAudit>;
+
+export function AuditSecureStringKeys(arg1:string,arg2:main.SSMFilter,arg3:boolean):Promise<main.KMSKeyAudit>;
+
+export function AvailableCSVColumns():Promise<main.CSVColumns>;
+
+export function CancelProcessing(arg1:string):Promise<void>;
+
+export function CheckAMIAvailability(arg1:string,arg2:Array<string>,arg3:Array<string>):Promise<main.AMIAvailabilityMatrix>;
+
+export function ChooseImportFile():Promise<string>;
+
+export function ClearAPITrace():Promise<void>;
+
+export function ClearResultCache():Promise<void>;
+
+export function ConfirmInstanceAction(arg1:string,arg2:string):Promise<string>;
+
+export function ConfirmInstanceRefresh(arg1:string):Promise<string>;
+
+export function ConfirmLaunchTemplateUpdate(arg1:string,arg2:string):Promise<string>;
+
+export function ConfirmParameterWrite(arg1:string,arg2:string):Promise<string>;
+
+export function CopyParameters(arg1:string,arg2:string,arg3:string,arg4:main.ParameterTransform,arg5:string,arg6:boolean):Promise<main.ParameterCopyResult>;
+
+export function CopyToClipboard(arg1:main.ClipboardRequest):Promise<number>;
+
+export function DeleteParameter(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DeletePreset(arg1:string):Promise<void>;
+
+export function DeleteScan(arg1:number):Promise<void>;
+
+export function DiffParameters(arg1:string,arg2:string,arg3:string,arg4:string):Promise<main.ParameterDiff>;
+
+export function DiffScans(arg1:number,arg2:number):Promise<main.ScanDiff>;
+
+export function EmailReport(arg1:main.AWSResult,arg2:Array<string>):Promise<void>;
+
+export function ExportCSV(arg1:main.AWSResult,arg2:string,arg3:main.CSVColumns):Promise<Array<string>>;
+
+export function ExportParameters(arg1:string,arg2:main.SSMFilter,arg3:string,arg4:boolean):Promise<string>;
+
+export function ExportParametersToFile(arg1:string,arg2:main.SSMFilter,arg3:string,arg4:boolean,arg5:string):Promise<void>;
+
+export function ExportReport(arg1:main.AWSResult,arg2:string,arg3:string):Promise<void>;
+
+export function ExportResultCSV(arg1:main.AWSResult,arg2:main.CSVColumns):Promise<Array<string>>;
+
+export function ExportResultReport(arg1:main.AWSResult,arg2:string):Promise<string>;
+
+export function ExportResultSARIF(arg1:main.AWSResult):Promise<string>;
+
+export function ExportResultXLSX(arg1:main.AWSResult):Promise<string>;
+
+export function ExportSARIF(arg1:main.AWSResult,arg2:string):Promise<void>;
+
+export function ExportXLSX(arg1:main.AWSResult,arg2:string):Promise<void>;
+
+export function FindLatestAMI(arg1:string,arg2:string):Promise<main.AMIUpgrade>;
+
+export function FindOrphanedAMIs(arg1:string):Promise<main.OrphanReport>;
+
+export function GetAPITrace():Promise<Array<main.APICall>>;
+
+export function GetInstanceDetails(arg1:string,arg2:string,arg3:string):Promise<main.InstanceDetails>;
+
+export function GetLogLevel():Promise<string>;
+
+export function GetParameterHistory(arg1:string,arg2:string):Promise<Array<main.ParameterVersion>>;
+
+export function GetPreset(arg1:string):Promise<main.Preset>;
+
+export function GetProfileSettings(arg1:string):Promise<main.ProfileInput>;
+
+export function GetScan(arg1:number):Promise<main.AWSResult>;
+
+export function GetSessionStatus(arg1:string):Promise<main.SessionStatus>;
+
+export function GroupByAMI(arg1:main.AWSResult):Promise<Array<main.AMIGroup>>;
+
+export function ImportParameters(arg1:string,arg2:main.ParameterImportOptions,arg3:string):Promise<main.ParameterImportResult>;
+
+export function InvalidateProfile(arg1:string):Promise<void>;
+
+export function LastCachedResult(arg1:string):Promise<main.AWSResult>;
+
+export function ListFavorites():Promise<Array<main.Favorite>>;
+
+export function ListPresets():Promise<Array<main.Preset>>;
+
+export function ListProfiles():Promise<Array<main.ProfileInfo>>;
+
+export function ListRegions(arg1:string):Promise<Array<main.RegionInfo>>;
+
+export function ListScans(arg1:string):Promise<Array<main.ScanSummary>>;
+
+export function LoadUserPrefs():Promise<main.UserPrefs>;
+
+export function LogFilePath():Promise<string>;
+
+export function NextScheduledRun(arg1:string):Promise<string>;
+
+export function OverwriteParameter(arg1:string,arg2:main.ParameterInput,arg3:string):Promise<number>;
+
+export function PinFavorite(arg1:main.Favorite):Promise<void>;
+
+export function PingEndpoint(arg1:string):Promise<void>;
+
+export function PreviewParameterImport(arg1:string,arg2:main.ParameterImportOptions):Promise<Array<main.ParameterImportEntry>>;
+
+export function ProcessRequest(arg1:main.ProcessingRequest):Promise<main.AWSResult>;
 
 export function Processing(arg1:string,arg2:string):Promise<main.AWSResult>;
+
+export function ProcessingMulti(arg1:Array<string>,arg2:string):Promise<main.MultiProfileResult>;
+
+export function ProcessingMultiRegion(arg1:string,arg2:Array<string>,arg3:string):Promise<main.AWSResult>;
+
+export function ProcessingOrg(arg1:main.OrgScanRequest):Promise<main.OrgScanResult>;
+
+export function ProcessingWithFilter(arg1:string,arg2:main.SSMFilter):Promise<main.AWSResult>;
+
+export function PublishResult(arg1:main.AWSResult,arg2:string):Promise<void>;
+
+export function PutParameter(arg1:string,arg2:main.ParameterInput,arg3:string):Promise<number>;
+
+export function RebootInstance(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<main.InstanceActionResult>;
+
+export function SavePreset(arg1:main.Preset):Promise<void>;
+
+export function SaveProfile(arg1:main.ProfileInput):Promise<string>;
+
+export function SaveUserPrefs(arg1:main.UserPrefs):Promise<void>;
+
+export function SetAPITracing(arg1:boolean):Promise<void>;
+
+export function SetLogLevel(arg1:string):Promise<void>;
+
+export function SetMFAPromptProvider(arg1:any):Promise<void>;
+
+export function SetMFATokenProvider(arg1:any):Promise<void>;
+
+export function SetRetryConfig(arg1:number,arg2:time.Duration):Promise<void>;
+
+export function SetWriteMode(arg1:boolean):Promise<void>;
+
+export function StartInstance(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<main.InstanceActionResult>;
+
+export function StartInstanceRefresh(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
+
+export function StartWatch(arg1:string,arg2:number):Promise<void>;
+
+export function StopInstance(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<main.InstanceActionResult>;
+
+export function StopWatch(arg1:string):Promise<void>;
+
+export function SubmitMFAToken(arg1:string):Promise<void>;
+
+export function Summary(arg1:string,arg2:string):Promise<main.AWSSummary>;
+
+export function TestNotifications():Promise<void>;
+
+export function TestProfile(arg1:string):Promise<main.ProfileTestResult>;
+
+export function TraceAMILineage(arg1:string,arg2:string):Promise<main.AMILineage>;
+
+export function UnpinFavorite(arg1:main.Favorite):Promise<void>;
+
+export function UpdateLaunchTemplateAMI(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:boolean,arg7:boolean):Promise<main.LaunchTemplateUpdateResult>;
+
+export function ValidatePolicyFile(arg1:string):Promise<void>;
+
+export function WatchedProfiles():Promise<Array<string>>;
+
+export function WriteModeEnabled():Promise<boolean>;
@@ -2,10 +2,362 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AuditAMIPermissions(arg1, arg2) {
+  return window['go']['main']['App']['AuditAMIPermissions'](arg1, arg2);
+}
+
+export function AuditLaunchTemplates(arg1) {
+  return window['go']['main']['App']['AuditLaunchTemplates'](arg1);
+}
+
+export function AuditSecureStringKeys(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AuditSecureStringKeys'](arg1, arg2, arg3);
+}
+
+export function AvailableCSVColumns() {
+  return window['go']['main']['App']['AvailableCSVColumns']();
+}
+
+export function CancelProcessing(arg1) {
+  return window['go']['main']['App']['CancelProcessing'](arg1);
+}
+
+export function CheckAMIAvailability(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CheckAMIAvailability'](arg1, arg2, arg3);
+}
+
+export function ChooseImportFile() {
+  return window['go']['main']['App']['ChooseImportFile']();
+}
+
+export function ClearAPITrace() {
+  return window['go']['main']['App']['ClearAPITrace']();
+}
+
+export function ClearResultCache() {
+  return window['go']['main']['App']['ClearResultCache']();
+}
+
+export function ConfirmInstanceAction(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmInstanceAction'](arg1, arg2);
+}
+
+export function ConfirmInstanceRefresh(arg1) {
+  return window['go']['main']['App']['ConfirmInstanceRefresh'](arg1);
+}
+
+export function ConfirmLaunchTemplateUpdate(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmLaunchTemplateUpdate'](arg1, arg2);
+}
+
+export function ConfirmParameterWrite(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmParameterWrite'](arg1, arg2);
+}
+
+export function CopyParameters(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['CopyParameters'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function CopyToClipboard(arg1) {
+  return window['go']['main']['App']['CopyToClipboard'](arg1);
+}
+
+export function DeleteParameter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteParameter'](arg1, arg2, arg3);
+}
+
+export function DeletePreset(arg1) {
+  return window['go']['main']['App']['DeletePreset'](arg1);
+}
+
+export function DeleteScan(arg1) {
+  return window['go']['main']['App']['DeleteScan'](arg1);
+}
+
+export function DiffParameters(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DiffParameters'](arg1, arg2, arg3, arg4);
+}
+
+export function DiffScans(arg1, arg2) {
+  return window['go']['main']['App']['DiffScans'](arg1, arg2);
+}
+
+export function EmailReport(arg1, arg2) {
+  return window['go']['main']['App']['EmailReport'](arg1, arg2);
+}
+
+export function ExportCSV(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportCSV'](arg1, arg2, arg3);
+}
+
+export function ExportParameters(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportParameters'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportParametersToFile(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['ExportParametersToFile'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ExportReport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportReport'](arg1, arg2, arg3);
+}
+
+export function ExportResultCSV(arg1, arg2) {
+  return window['go']['main']['App']['ExportResultCSV'](arg1, arg2);
+}
+
+export function ExportResultReport(arg1, arg2) {
+  return window['go']['main']['App']['ExportResultReport'](arg1, arg2);
+}
+
+export function ExportResultSARIF(arg1) {
+  return window['go']['main']['App']['ExportResultSARIF'](arg1);
+}
+
+export function ExportResultXLSX(arg1) {
+  return window['go']['main']['App']['ExportResultXLSX'](arg1);
+}
+
+export function ExportSARIF(arg1, arg2) {
+  return window['go']['main']['App']['ExportSARIF'](arg1, arg2);
+}
+
+export function ExportXLSX(arg1, arg2) {
+  return window['go']['main']['App']['ExportXLSX'](arg1, arg2);
+}
+
+export function FindLatestAMI(arg1, arg2) {
+  return window['go']['main']['App']['FindLatestAMI'](arg1, arg2);
+}
+
+export function FindOrphanedAMIs(arg1) {
+  return window['go']['main']['App']['FindOrphanedAMIs'](arg1);
+}
+
+export function GetAPITrace() {
+  return window['go']['main']['App']['GetAPITrace']();
+}
+
+export function GetInstanceDetails(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetInstanceDetails'](arg1, arg2, arg3);
+}
+
+export function GetLogLevel() {
+  return window['go']['main']['App']['GetLogLevel']();
+}
+
+export function GetParameterHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetParameterHistory'](arg1, arg2);
+}
+
+export function GetPreset(arg1) {
+  return window['go']['main']['App']['GetPreset'](arg1);
+}
+
+export function GetProfileSettings(arg1) {
+  return window['go']['main']['App']['GetProfileSettings'](arg1);
+}
+
+export function GetScan(arg1) {
+  return window['go']['main']['App']['GetScan'](arg1);
+}
+
+export function GetSessionStatus(arg1) {
+  return window['go']['main']['App']['GetSessionStatus'](arg1);
+}
+
+export function GroupByAMI(arg1) {
+  return window['go']['main']['App']['GroupByAMI'](arg1);
+}
+
+export function ImportParameters(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportParameters'](arg1, arg2, arg3);
+}
+
+export function InvalidateProfile(arg1) {
+  return window['go']['main']['App']['InvalidateProfile'](arg1);
+}
+
+export function LastCachedResult(arg1) {
+  return window['go']['main']['App']['LastCachedResult'](arg1);
+}
+
+export function ListFavorites() {
+  return window['go']['main']['App']['ListFavorites']();
+}
+
+export function ListPresets() {
+  return window['go']['main']['App']['ListPresets']();
+}
+
 export function ListProfiles() {
   return window['go']['main']['App']['ListProfiles']();
 }
 
+export function ListRegions(arg1) {
+  return window['go']['main']['App']['ListRegions'](arg1);
+}
+
+export function ListScans(arg1) {
+  return window['go']['main']['App']['ListScans'](arg1);
+}
+
+export function LoadUserPrefs() {
+  return window['go']['main']['App']['LoadUserPrefs']();
+}
+
+export function LogFilePath() {
+  return window['go']['main']['App']['LogFilePath']();
+}
+
+export function NextScheduledRun(arg1) {
+  return window['go']['main']['App']['NextScheduledRun'](arg1);
+}
+
+export function OverwriteParameter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['OverwriteParameter'](arg1, arg2, arg3);
+}
+
+export function PinFavorite(arg1) {
+  return window['go']['main']['App']['PinFavorite'](arg1);
+}
+
+export function PingEndpoint(arg1) {
+  return window['go']['main']['App']['PingEndpoint'](arg1);
+}
+
+export function PreviewParameterImport(arg1, arg2) {
+  return window['go']['main']['App']['PreviewParameterImport'](arg1, arg2);
+}
+
+export function ProcessRequest(arg1) {
+  return window['go']['main']['App']['ProcessRequest'](arg1);
+}
+
 export function Processing(arg1, arg2) {
   return window['go']['main']['App']['Processing'](arg1, arg2);
 }
+
+export function ProcessingMulti(arg1, arg2) {
+  return window['go']['main']['App']['ProcessingMulti'](arg1, arg2);
+}
+
+export function ProcessingMultiRegion(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ProcessingMultiRegion'](arg1, arg2, arg3);
+}
+
+export function ProcessingOrg(arg1) {
+  return window['go']['main']['App']['ProcessingOrg'](arg1);
+}
+
+export function ProcessingWithFilter(arg1, arg2) {
+  return window['go']['main']['App']['ProcessingWithFilter'](arg1, arg2);
+}
+
+export function PublishResult(arg1, arg2) {
+  return window['go']['main']['App']['PublishResult'](arg1, arg2);
+}
+
+export function PutParameter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PutParameter'](arg1, arg2, arg3);
+}
+
+export function RebootInstance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['RebootInstance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SavePreset(arg1) {
+  return window['go']['main']['App']['SavePreset'](arg1);
+}
+
+export function SaveProfile(arg1) {
+  return window['go']['main']['App']['SaveProfile'](arg1);
+}
+
+export function SaveUserPrefs(arg1) {
+  return window['go']['main']['App']['SaveUserPrefs'](arg1);
+}
+
+export function SetAPITracing(arg1) {
+  return window['go']['main']['App']['SetAPITracing'](arg1);
+}
+
+export function SetLogLevel(arg1) {
+  return window['go']['main']['App']['SetLogLevel'](arg1);
+}
+
+export function SetMFAPromptProvider(arg1) {
+  return window['go']['main']['App']['SetMFAPromptProvider'](arg1);
+}
+
+export function SetMFATokenProvider(arg1) {
+  return window['go']['main']['App']['SetMFATokenProvider'](arg1);
+}
+
+export function SetRetryConfig(arg1, arg2) {
+  return window['go']['main']['App']['SetRetryConfig'](arg1, arg2);
+}
+
+export function SetWriteMode(arg1) {
+  return window['go']['main']['App']['SetWriteMode'](arg1);
+}
+
+export function StartInstance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['StartInstance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function StartInstanceRefresh(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['StartInstanceRefresh'](arg1, arg2, arg3, arg4);
+}
+
+export function StartWatch(arg1, arg2) {
+  return window['go']['main']['App']['StartWatch'](arg1, arg2);
+}
+
+export function StopInstance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['StopInstance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function StopWatch(arg1) {
+  return window['go']['main']['App']['StopWatch'](arg1);
+}
+
+export function SubmitMFAToken(arg1) {
+  return window['go']['main']['App']['SubmitMFAToken'](arg1);
+}
+
+export function Summary(arg1, arg2) {
+  return window['go']['main']['App']['Summary'](arg1, arg2);
+}
+
+export function TestNotifications() {
+  return window['go']['main']['App']['TestNotifications']();
+}
+
+export function TestProfile(arg1) {
+  return window['go']['main']['App']['TestProfile'](arg1);
+}
+
+export function TraceAMILineage(arg1, arg2) {
+  return window['go']['main']['App']['TraceAMILineage'](arg1, arg2);
+}
+
+export function UnpinFavorite(arg1) {
+  return window['go']['main']['App']['UnpinFavorite'](arg1);
+}
+
+export function UpdateLaunchTemplateAMI(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['UpdateLaunchTemplateAMI'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function ValidatePolicyFile(arg1) {
+  return window['go']['main']['App']['ValidatePolicyFile'](arg1);
+}
+
+export function WatchedProfiles() {
+  return window['go']['main']['App']['WatchedProfiles']();
+}
+
+export function WriteModeEnabled() {
+  return window['go']['main']['App']['WriteModeEnabled']();
+}
@@ -1,8 +1,189 @@
 export namespace main {
 	
+	export class AMIRegionAvailability {
+	    region: string;
+	    available: boolean;
+	    imageId: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIRegionAvailability(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.region = source["region"];
+	        this.available = source["available"];
+	        this.imageId = source["imageId"];
+	        this.error = source["error"];
+	    }
+	}
+	export class AMIAvailabilityRow {
+	    ami: string;
+	    name: string;
+	    ownerId: string;
+	    regions: AMIRegionAvailability[];
+	    missingRegions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIAvailabilityRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ami = source["ami"];
+	        this.name = source["name"];
+	        this.ownerId = source["ownerId"];
+	        this.regions = this.convertValues(source["regions"], AMIRegionAvailability);
+	        this.missingRegions = source["missingRegions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMIAvailabilityMatrix {
+	    sourceRegion: string;
+	    regions: string[];
+	    amis: AMIAvailabilityRow[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIAvailabilityMatrix(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceRegion = source["sourceRegion"];
+	        this.regions = source["regions"];
+	        this.amis = this.convertValues(source["amis"], AMIAvailabilityRow);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class AMIDrift {
+	    ami: string;
+	    name: string;
+	    from: number;
+	    to: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIDrift(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ami = source["ami"];
+	        this.name = source["name"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class SecurityExposure {
+	    groupId: string;
+	    port: number;
+	    service: string;
+	    cidr: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecurityExposure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.port = source["port"];
+	        this.service = source["service"];
+	        this.cidr = source["cidr"];
+	    }
+	}
+	export class SecurityGroupRef {
+	    id: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecurityGroupRef(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	    }
+	}
 	export class EC2Instance {
+	    instanceId: string;
 	    name: string;
+	    state: string;
+	    instanceType: string;
+	    availabilityZone: string;
+	    vpcId: string;
+	    subnetId: string;
+	    privateIp: string;
+	    publicIp: string;
+	    launchTime: string;
+	    platform: string;
+	    lifecycle: string;
 	    ami: string;
+	    region: string;
+	    accountId: string;
+	    amiName: string;
+	    amiDescription: string;
+	    amiOwner: string;
+	    amiCreationDate: string;
+	    amiAgeDays: number;
+	    amiStale: boolean;
+	    amiDeprecated: boolean;
+	    amiStatus: string;
+	    amiPublic: boolean;
+	    recommendedAmi: string;
+	    amiDaysBehind: number;
+	    hourlyCostUsd: number;
+	    monthlyCostUsd: number;
+	    securityGroups: SecurityGroupRef[];
+	    instanceProfileArn: string;
+	    iamRole: string;
+	    iamFinding: string;
+	    imdsHttpTokens: string;
+	    imdsV1Allowed: boolean;
+	    exposures: SecurityExposure[];
+	    nonCompliant: boolean;
+	    loadBalancers?: string[];
+	    stackName: string;
+	    stackLogicalId: string;
+	    unmanaged: boolean;
+	    pinned?: boolean;
+	    tags?: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
 	        return new EC2Instance(source);
@@ -10,22 +191,250 @@ export namespace main {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
 	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.instanceType = source["instanceType"];
+	        this.availabilityZone = source["availabilityZone"];
+	        this.vpcId = source["vpcId"];
+	        this.subnetId = source["subnetId"];
+	        this.privateIp = source["privateIp"];
+	        this.publicIp = source["publicIp"];
+	        this.launchTime = source["launchTime"];
+	        this.platform = source["platform"];
+	        this.lifecycle = source["lifecycle"];
 	        this.ami = source["ami"];
+	        this.region = source["region"];
+	        this.accountId = source["accountId"];
+	        this.amiName = source["amiName"];
+	        this.amiDescription = source["amiDescription"];
+	        this.amiOwner = source["amiOwner"];
+	        this.amiCreationDate = source["amiCreationDate"];
+	        this.amiAgeDays = source["amiAgeDays"];
+	        this.amiStale = source["amiStale"];
+	        this.amiDeprecated = source["amiDeprecated"];
+	        this.amiStatus = source["amiStatus"];
+	        this.amiPublic = source["amiPublic"];
+	        this.recommendedAmi = source["recommendedAmi"];
+	        this.amiDaysBehind = source["amiDaysBehind"];
+	        this.hourlyCostUsd = source["hourlyCostUsd"];
+	        this.monthlyCostUsd = source["monthlyCostUsd"];
+	        this.securityGroups = this.convertValues(source["securityGroups"], SecurityGroupRef);
+	        this.instanceProfileArn = source["instanceProfileArn"];
+	        this.iamRole = source["iamRole"];
+	        this.iamFinding = source["iamFinding"];
+	        this.imdsHttpTokens = source["imdsHttpTokens"];
+	        this.imdsV1Allowed = source["imdsV1Allowed"];
+	        this.exposures = this.convertValues(source["exposures"], SecurityExposure);
+	        this.nonCompliant = source["nonCompliant"];
+	        this.loadBalancers = source["loadBalancers"];
+	        this.stackName = source["stackName"];
+	        this.stackLogicalId = source["stackLogicalId"];
+	        this.unmanaged = source["unmanaged"];
+	        this.pinned = source["pinned"];
+	        this.tags = source["tags"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class AWSResult {
-	    parameters: string[];
+	export class AMIGroup {
+	    ami: string;
+	    count: number;
+	    deprecated: boolean;
 	    instances: EC2Instance[];
+	    regions: Record<string, number>;
+	    accounts: Record<string, number>;
 	
 	    static createFrom(source: any = {}) {
-	        return new AWSResult(source);
+	        return new AMIGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.parameters = source["parameters"];
+	        this.ami = source["ami"];
+	        this.count = source["count"];
+	        this.deprecated = source["deprecated"];
 	        this.instances = this.convertValues(source["instances"], EC2Instance);
+	        this.regions = source["regions"];
+	        this.accounts = source["accounts"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMIImage {
+	    id: string;
+	    name: string;
+	    ownerId: string;
+	    creationDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIImage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.ownerId = source["ownerId"];
+	        this.creationDate = source["creationDate"];
+	    }
+	}
+	export class LineageStep {
+	    image: AMIImage;
+	    region: string;
+	    via: string;
+	    missing: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LineageStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = this.convertValues(source["image"], AMIImage);
+	        this.region = source["region"];
+	        this.via = source["via"];
+	        this.missing = source["missing"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMILineage {
+	    steps: LineageStep[];
+	    complete: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMILineage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steps = this.convertValues(source["steps"], LineageStep);
+	        this.complete = source["complete"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMIPermissionFinding {
+	    image: AMIImage;
+	    public: boolean;
+	    sharedWith: string[];
+	    unknownAccounts: string[];
+	    organizations: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIPermissionFinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = this.convertValues(source["image"], AMIImage);
+	        this.public = source["public"];
+	        this.sharedWith = source["sharedWith"];
+	        this.unknownAccounts = source["unknownAccounts"];
+	        this.organizations = source["organizations"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMIPermissionAudit {
+	    region: string;
+	    ownedCount: number;
+	    publicCount: number;
+	    unknownCount: number;
+	    findings: AMIPermissionFinding[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIPermissionAudit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.region = source["region"];
+	        this.ownedCount = source["ownedCount"];
+	        this.publicCount = source["publicCount"];
+	        this.unknownCount = source["unknownCount"];
+	        this.findings = this.convertValues(source["findings"], AMIPermissionFinding);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -46,6 +455,1861 @@ export namespace main {
 		    return a;
 		}
 	}
+	
+	
+	export class SnapshotInfo {
+	    id: string;
+	    sizeGiB: number;
+	    storageTier: string;
+	    monthlyCostUsd: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SnapshotInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sizeGiB = source["sizeGiB"];
+	        this.storageTier = source["storageTier"];
+	        this.monthlyCostUsd = source["monthlyCostUsd"];
+	    }
+	}
+	export class AMIStorage {
+	    image: AMIImage;
+	    region: string;
+	    snapshots: SnapshotInfo[];
+	    sizeGiB: number;
+	    monthlyCostUsd: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIStorage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = this.convertValues(source["image"], AMIImage);
+	        this.region = source["region"];
+	        this.snapshots = this.convertValues(source["snapshots"], SnapshotInfo);
+	        this.sizeGiB = source["sizeGiB"];
+	        this.monthlyCostUsd = source["monthlyCostUsd"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AMIUpgrade {
+	    current: AMIImage;
+	    latest?: AMIImage;
+	    updateAvailable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AMIUpgrade(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = this.convertValues(source["current"], AMIImage);
+	        this.latest = this.convertValues(source["latest"], AMIImage);
+	        this.updateAvailable = source["updateAvailable"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class APICall {
+	    service: string;
+	    operation: string;
+	    region: string;
+	    startedAt: string;
+	    durationMs: number;
+	    retries: number;
+	    statusCode: number;
+	    requestId: string;
+	    errorCode?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new APICall(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.operation = source["operation"];
+	        this.region = source["region"];
+	        this.startedAt = source["startedAt"];
+	        this.durationMs = source["durationMs"];
+	        this.retries = source["retries"];
+	        this.statusCode = source["statusCode"];
+	        this.requestId = source["requestId"];
+	        this.errorCode = source["errorCode"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ASGInstanceAMI {
+	    instanceId: string;
+	    ami: string;
+	    templateVersion: string;
+	    drifted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ASGInstanceAMI(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
+	        this.ami = source["ami"];
+	        this.templateVersion = source["templateVersion"];
+	        this.drifted = source["drifted"];
+	    }
+	}
+	export class ASGDrift {
+	    autoScalingGroup: string;
+	    templateId: string;
+	    templateName: string;
+	    templateVersion: string;
+	    version: number;
+	    configuredAmi: string;
+	    resolvedAmi: string;
+	    unresolved: boolean;
+	    latestAmi: string;
+	    instances: ASGInstanceAMI[];
+	    driftedCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ASGDrift(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.autoScalingGroup = source["autoScalingGroup"];
+	        this.templateId = source["templateId"];
+	        this.templateName = source["templateName"];
+	        this.templateVersion = source["templateVersion"];
+	        this.version = source["version"];
+	        this.configuredAmi = source["configuredAmi"];
+	        this.resolvedAmi = source["resolvedAmi"];
+	        this.unresolved = source["unresolved"];
+	        this.latestAmi = source["latestAmi"];
+	        this.instances = this.convertValues(source["instances"], ASGInstanceAMI);
+	        this.driftedCount = source["driftedCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ScanError {
+	    region: string;
+	    service?: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScanError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.region = source["region"];
+	        this.service = source["service"];
+	        this.error = source["error"];
+	    }
+	}
+	export class CallerIdentity {
+	    accountId: string;
+	    arn: string;
+	    userId: string;
+	    accountAlias?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CallerIdentity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accountId = source["accountId"];
+	        this.arn = source["arn"];
+	        this.userId = source["userId"];
+	        this.accountAlias = source["accountAlias"];
+	    }
+	}
+	export class ComplianceSummary {
+	    goldenAmis: number;
+	    compliant: number;
+	    nonCompliant: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComplianceSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.goldenAmis = source["goldenAmis"];
+	        this.compliant = source["compliant"];
+	        this.nonCompliant = source["nonCompliant"];
+	    }
+	}
+	export class VolumeInfo {
+	    deviceName: string;
+	    volumeId: string;
+	    sizeGiB: number;
+	    volumeType: string;
+	    encrypted: boolean;
+	    deleteOnTermination: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceName = source["deviceName"];
+	        this.volumeId = source["volumeId"];
+	        this.sizeGiB = source["sizeGiB"];
+	        this.volumeType = source["volumeType"];
+	        this.encrypted = source["encrypted"];
+	        this.deleteOnTermination = source["deleteOnTermination"];
+	    }
+	}
+	export class VolumeCheck {
+	    instanceId: string;
+	    region: string;
+	    volume: VolumeInfo;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
+	        this.region = source["region"];
+	        this.volume = this.convertValues(source["volume"], VolumeInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CheckResults {
+	    volumes?: VolumeCheck[];
+	    unencryptedCount: number;
+	    iamFindingCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CheckResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.volumes = this.convertValues(source["volumes"], VolumeCheck);
+	        this.unencryptedCount = source["unencryptedCount"];
+	        this.iamFindingCount = source["iamFindingCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AmiStorageReport {
+	    images: AMIStorage[];
+	    totalSizeGiB: number;
+	    totalMonthlyCostUsd: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AmiStorageReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.images = this.convertValues(source["images"], AMIStorage);
+	        this.totalSizeGiB = source["totalSizeGiB"];
+	        this.totalMonthlyCostUsd = source["totalMonthlyCostUsd"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LifecycleCount {
+	    lifecycle: string;
+	    instances: number;
+	    stale: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lifecycle = source["lifecycle"];
+	        this.instances = source["instances"];
+	        this.stale = source["stale"];
+	    }
+	}
+	export class LambdaFunction {
+	    name: string;
+	    arn: string;
+	    region: string;
+	    runtime: string;
+	    packageType: string;
+	    architectures: string[];
+	    layers: string[];
+	    lastModified: string;
+	    runtimeStatus: string;
+	    deprecationDate?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LambdaFunction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.arn = source["arn"];
+	        this.region = source["region"];
+	        this.runtime = source["runtime"];
+	        this.packageType = source["packageType"];
+	        this.architectures = source["architectures"];
+	        this.layers = source["layers"];
+	        this.lastModified = source["lastModified"];
+	        this.runtimeStatus = source["runtimeStatus"];
+	        this.deprecationDate = source["deprecationDate"];
+	    }
+	}
+	export class SecretInfo {
+	    name: string;
+	    arn: string;
+	    region: string;
+	    description: string;
+	    kmsKeyId: string;
+	    rotationEnabled: boolean;
+	    lastChangedDate: string;
+	    lastAccessedDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecretInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.arn = source["arn"];
+	        this.region = source["region"];
+	        this.description = source["description"];
+	        this.kmsKeyId = source["kmsKeyId"];
+	        this.rotationEnabled = source["rotationEnabled"];
+	        this.lastChangedDate = source["lastChangedDate"];
+	        this.lastAccessedDate = source["lastAccessedDate"];
+	    }
+	}
+	export class ParameterInfo {
+	    name: string;
+	    region: string;
+	    type: string;
+	    version: number;
+	    tier: string;
+	    lastModifiedDate: string;
+	    lastModifiedUser: string;
+	    pinned?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.region = source["region"];
+	        this.type = source["type"];
+	        this.version = source["version"];
+	        this.tier = source["tier"];
+	        this.lastModifiedDate = source["lastModifiedDate"];
+	        this.lastModifiedUser = source["lastModifiedUser"];
+	        this.pinned = source["pinned"];
+	    }
+	}
+	export class AWSResult {
+	    parameters: ParameterInfo[];
+	    instances: EC2Instance[];
+	    secrets?: SecretInfo[];
+	    lambdaFunctions?: LambdaFunction[];
+	    services?: Record<string, Array<ServiceResource>>;
+	    staleAfterDays: number;
+	    lifecycles: LifecycleCount[];
+	    estimatedMonthlyCostUsd: number;
+	    amiStorageReport?: AmiStorageReport;
+	    checks?: CheckResults;
+	    compliance?: ComplianceSummary;
+	    identity?: CallerIdentity;
+	    assumedRole?: string;
+	    roleChain?: string[];
+	    policyResults?: policy.Result[];
+	    errors?: ScanError[];
+	    scannedAt: string;
+	    cached?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AWSResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.parameters = this.convertValues(source["parameters"], ParameterInfo);
+	        this.instances = this.convertValues(source["instances"], EC2Instance);
+	        this.secrets = this.convertValues(source["secrets"], SecretInfo);
+	        this.lambdaFunctions = this.convertValues(source["lambdaFunctions"], LambdaFunction);
+	        this.services = this.convertValues(source["services"], Array<ServiceResource>, true);
+	        this.staleAfterDays = source["staleAfterDays"];
+	        this.lifecycles = this.convertValues(source["lifecycles"], LifecycleCount);
+	        this.estimatedMonthlyCostUsd = source["estimatedMonthlyCostUsd"];
+	        this.amiStorageReport = this.convertValues(source["amiStorageReport"], AmiStorageReport);
+	        this.checks = this.convertValues(source["checks"], CheckResults);
+	        this.compliance = this.convertValues(source["compliance"], ComplianceSummary);
+	        this.identity = this.convertValues(source["identity"], CallerIdentity);
+	        this.assumedRole = source["assumedRole"];
+	        this.roleChain = source["roleChain"];
+	        this.policyResults = this.convertValues(source["policyResults"], policy.Result);
+	        this.errors = this.convertValues(source["errors"], ScanError);
+	        this.scannedAt = source["scannedAt"];
+	        this.cached = source["cached"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AWSSummary {
+	    parameterCount: number;
+	    instanceCount: number;
+	    instancesByState: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new AWSSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.parameterCount = source["parameterCount"];
+	        this.instanceCount = source["instanceCount"];
+	        this.instancesByState = source["instancesByState"];
+	    }
+	}
+	export class AccountResult {
+	    accountId: string;
+	    accountName: string;
+	    roleArn?: string;
+	    result?: AWSResult;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccountResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accountId = source["accountId"];
+	        this.accountName = source["accountName"];
+	        this.roleArn = source["roleArn"];
+	        this.result = this.convertValues(source["result"], AWSResult);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CSVColumns {
+	    instances: string[];
+	    parameters: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVColumns(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instances = source["instances"];
+	        this.parameters = source["parameters"];
+	    }
+	}
+	
+	export class CheckOptions {
+	    volumes: boolean;
+	    iam: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CheckOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.volumes = source["volumes"];
+	        this.iam = source["iam"];
+	    }
+	}
+	
+	export class ClipboardRequest {
+	    result: AWSResult;
+	    kind: string;
+	    format: string;
+	    columns: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.result = this.convertValues(source["result"], AWSResult);
+	        this.kind = source["kind"];
+	        this.format = source["format"];
+	        this.columns = source["columns"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class EC2Filter {
+	    tags: string[];
+	    states: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EC2Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tags = source["tags"];
+	        this.states = source["states"];
+	    }
+	}
+	
+	export class Favorite {
+	    kind: string;
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Favorite(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.id = source["id"];
+	    }
+	}
+	export class FieldChange {
+	    field: string;
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class InstanceActionResult {
+	    instanceId: string;
+	    action: string;
+	    dryRun: boolean;
+	    previousState: string;
+	    currentState: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceActionResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
+	        this.action = source["action"];
+	        this.dryRun = source["dryRun"];
+	        this.previousState = source["previousState"];
+	        this.currentState = source["currentState"];
+	        this.message = source["message"];
+	    }
+	}
+	export class InstanceChange {
+	    instanceId: string;
+	    name: string;
+	    region: string;
+	    changes: FieldChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
+	        this.name = source["name"];
+	        this.region = source["region"];
+	        this.changes = this.convertValues(source["changes"], FieldChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NetworkInterfaceInfo {
+	    id: string;
+	    subnetId: string;
+	    vpcId: string;
+	    privateIp: string;
+	    publicIp: string;
+	    securityGroupIds: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkInterfaceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.subnetId = source["subnetId"];
+	        this.vpcId = source["vpcId"];
+	        this.privateIp = source["privateIp"];
+	        this.publicIp = source["publicIp"];
+	        this.securityGroupIds = source["securityGroupIds"];
+	    }
+	}
+	export class InstanceDetails {
+	    instance: EC2Instance;
+	    networkInterfaces: NetworkInterfaceInfo[];
+	    volumes: VolumeInfo[];
+	    securityGroups: SecurityGroupRef[];
+	    iamInstanceProfile: string;
+	    hasUserData: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instance = this.convertValues(source["instance"], EC2Instance);
+	        this.networkInterfaces = this.convertValues(source["networkInterfaces"], NetworkInterfaceInfo);
+	        this.volumes = this.convertValues(source["volumes"], VolumeInfo);
+	        this.securityGroups = this.convertValues(source["securityGroups"], SecurityGroupRef);
+	        this.iamInstanceProfile = source["iamInstanceProfile"];
+	        this.hasUserData = source["hasUserData"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SecureStringKey {
+	    name: string;
+	    keyId: string;
+	    defaultKey: boolean;
+	    flagged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecureStringKey(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.keyId = source["keyId"];
+	        this.defaultKey = source["defaultKey"];
+	        this.flagged = source["flagged"];
+	    }
+	}
+	export class KMSKeyAudit {
+	    parameters: SecureStringKey[];
+	    keys: Record<string, number>;
+	    defaultCount: number;
+	    flaggedCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KMSKeyAudit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.parameters = this.convertValues(source["parameters"], SecureStringKey);
+	        this.keys = source["keys"];
+	        this.defaultCount = source["defaultCount"];
+	        this.flaggedCount = source["flaggedCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LaunchTemplateVersionAMI {
+	    templateId: string;
+	    templateName: string;
+	    version: number;
+	    default: boolean;
+	    latest: boolean;
+	    imageId: string;
+	    createTime: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LaunchTemplateVersionAMI(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.templateId = source["templateId"];
+	        this.templateName = source["templateName"];
+	        this.version = source["version"];
+	        this.default = source["default"];
+	        this.latest = source["latest"];
+	        this.imageId = source["imageId"];
+	        this.createTime = source["createTime"];
+	    }
+	}
+	export class LaunchTemplateAudit {
+	    region: string;
+	    versions: LaunchTemplateVersionAMI[];
+	    autoScalingGroups: ASGDrift[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LaunchTemplateAudit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.region = source["region"];
+	        this.versions = this.convertValues(source["versions"], LaunchTemplateVersionAMI);
+	        this.autoScalingGroups = this.convertValues(source["autoScalingGroups"], ASGDrift);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LaunchTemplateUpdateResult {
+	    templateId: string;
+	    ami: string;
+	    dryRun: boolean;
+	    version: number;
+	    setDefault: boolean;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LaunchTemplateUpdateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.templateId = source["templateId"];
+	        this.ami = source["ami"];
+	        this.dryRun = source["dryRun"];
+	        this.version = source["version"];
+	        this.setDefault = source["setDefault"];
+	        this.message = source["message"];
+	    }
+	}
+	
+	
+	
+	export class ProfileResult {
+	    profile: string;
+	    accountId: string;
+	    result?: AWSResult;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.accountId = source["accountId"];
+	        this.result = this.convertValues(source["result"], AWSResult);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MultiProfileResult {
+	    profiles: ProfileResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MultiProfileResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profiles = this.convertValues(source["profiles"], ProfileResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SSMFilter {
+	    namePrefix: string;
+	    match: string;
+	    types: string[];
+	    keyId: string;
+	    tags: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SSMFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.namePrefix = source["namePrefix"];
+	        this.match = source["match"];
+	        this.types = source["types"];
+	        this.keyId = source["keyId"];
+	        this.tags = source["tags"];
+	    }
+	}
+	export class ProcessingRequest {
+	    requestId: string;
+	    profile: string;
+	    regions: string[];
+	    filter: SSMFilter;
+	    refresh: boolean;
+	    forceRefresh: boolean;
+	    instanceFilter: EC2Filter;
+	    staleAfterDays: number;
+	    compareLatest: boolean;
+	    estimateCost: boolean;
+	    checks: CheckOptions;
+	    secrets: boolean;
+	    lambda: boolean;
+	    stackMapping: boolean;
+	    services: string[];
+	    approvedRegistries: string[];
+	    storageReport: boolean;
+	    goldenAmis: string[];
+	    goldenAmiParameter: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProcessingRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.requestId = source["requestId"];
+	        this.profile = source["profile"];
+	        this.regions = source["regions"];
+	        this.filter = this.convertValues(source["filter"], SSMFilter);
+	        this.refresh = source["refresh"];
+	        this.forceRefresh = source["forceRefresh"];
+	        this.instanceFilter = this.convertValues(source["instanceFilter"], EC2Filter);
+	        this.staleAfterDays = source["staleAfterDays"];
+	        this.compareLatest = source["compareLatest"];
+	        this.estimateCost = source["estimateCost"];
+	        this.checks = this.convertValues(source["checks"], CheckOptions);
+	        this.secrets = source["secrets"];
+	        this.lambda = source["lambda"];
+	        this.stackMapping = source["stackMapping"];
+	        this.services = source["services"];
+	        this.approvedRegistries = source["approvedRegistries"];
+	        this.storageReport = source["storageReport"];
+	        this.goldenAmis = source["goldenAmis"];
+	        this.goldenAmiParameter = source["goldenAmiParameter"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrgScanRequest {
+	    scan: ProcessingRequest;
+	    auditRole: string;
+	    externalId: string;
+	    accounts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OrgScanRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scan = this.convertValues(source["scan"], ProcessingRequest);
+	        this.auditRole = source["auditRole"];
+	        this.externalId = source["externalId"];
+	        this.accounts = source["accounts"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrgScanResult {
+	    managementAccountId: string;
+	    accounts: AccountResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OrgScanResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.managementAccountId = source["managementAccountId"];
+	        this.accounts = this.convertValues(source["accounts"], AccountResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrphanedAMI {
+	    image: AMIImage;
+	    snapshotIds: string[];
+	    snapshotSizeGiB: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrphanedAMI(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = this.convertValues(source["image"], AMIImage);
+	        this.snapshotIds = source["snapshotIds"];
+	        this.snapshotSizeGiB = source["snapshotSizeGiB"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrphanReport {
+	    region: string;
+	    ownedCount: number;
+	    orphaned: OrphanedAMI[];
+	    totalSnapshotSizeGiB: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrphanReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.region = source["region"];
+	        this.ownedCount = source["ownedCount"];
+	        this.orphaned = this.convertValues(source["orphaned"], OrphanedAMI);
+	        this.totalSnapshotSizeGiB = source["totalSnapshotSizeGiB"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ParameterEntry {
+	    key: string;
+	    name: string;
+	    type: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	    }
+	}
+	export class ParameterChange {
+	    key: string;
+	    a: ParameterEntry;
+	    b: ParameterEntry;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.a = this.convertValues(source["a"], ParameterEntry);
+	        this.b = this.convertValues(source["b"], ParameterEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ParameterCopyResult {
+	    dryRun: boolean;
+	    create: ParameterEntry[];
+	    update: ParameterChange[];
+	    unchanged: number;
+	    written: string[];
+	    failed: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterCopyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.create = this.convertValues(source["create"], ParameterEntry);
+	        this.update = this.convertValues(source["update"], ParameterChange);
+	        this.unchanged = source["unchanged"];
+	        this.written = source["written"];
+	        this.failed = source["failed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ParameterDiff {
+	    pathA: string;
+	    pathB: string;
+	    added: ParameterEntry[];
+	    removed: ParameterEntry[];
+	    changed: ParameterChange[];
+	    unchanged: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pathA = source["pathA"];
+	        this.pathB = source["pathB"];
+	        this.added = this.convertValues(source["added"], ParameterEntry);
+	        this.removed = this.convertValues(source["removed"], ParameterEntry);
+	        this.changed = this.convertValues(source["changed"], ParameterChange);
+	        this.unchanged = source["unchanged"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ParameterImportEntry {
+	    name: string;
+	    value: string;
+	    exists: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterImportEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.exists = source["exists"];
+	    }
+	}
+	export class ParameterImportOptions {
+	    file: string;
+	    prefix: string;
+	    type: string;
+	    keyId: string;
+	    overwrite: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.prefix = source["prefix"];
+	        this.type = source["type"];
+	        this.keyId = source["keyId"];
+	        this.overwrite = source["overwrite"];
+	    }
+	}
+	export class ParameterImportResult {
+	    created: string[];
+	    overwritten: string[];
+	    skipped: string[];
+	    failed: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.created = source["created"];
+	        this.overwritten = source["overwritten"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	    }
+	}
+	
+	export class ParameterInput {
+	    name: string;
+	    value: string;
+	    type: string;
+	    keyId: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.type = source["type"];
+	        this.keyId = source["keyId"];
+	        this.description = source["description"];
+	    }
+	}
+	export class ParameterTransform {
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterTransform(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class ParameterVersion {
+	    version: number;
+	    type: string;
+	    value: string;
+	    lastModifiedDate: string;
+	    lastModifiedUser: string;
+	    labels: string[];
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParameterVersion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.lastModifiedDate = source["lastModifiedDate"];
+	        this.lastModifiedUser = source["lastModifiedUser"];
+	        this.labels = source["labels"];
+	        this.description = source["description"];
+	    }
+	}
+	export class Preset {
+	    name: string;
+	    request: ProcessingRequest;
+	    schedule: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Preset(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.request = this.convertValues(source["request"], ProcessingRequest);
+	        this.schedule = source["schedule"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SSOSession {
+	    profile: string;
+	    name: string;
+	    startUrl: string;
+	    region: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SSOSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.name = source["name"];
+	        this.startUrl = source["startUrl"];
+	        this.region = source["region"];
+	    }
+	}
+	export class ProfileInfo {
+	    name: string;
+	    kind: string;
+	    region: string;
+	    endpointUrl: string;
+	    output: string;
+	    sso?: SSOSession;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.region = source["region"];
+	        this.endpointUrl = source["endpointUrl"];
+	        this.output = source["output"];
+	        this.sso = this.convertValues(source["sso"], SSOSession);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileInput {
+	    name: string;
+	    region: string;
+	    ssoSession: string;
+	    endpointUrl: string;
+	    roleArn: string;
+	    sourceProfile: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.region = source["region"];
+	        this.ssoSession = source["ssoSession"];
+	        this.endpointUrl = source["endpointUrl"];
+	        this.roleArn = source["roleArn"];
+	        this.sourceProfile = source["sourceProfile"];
+	    }
+	}
+	
+	export class ProfileTestResult {
+	    profile: string;
+	    status: string;
+	    accountId?: string;
+	    arn?: string;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileTestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.status = source["status"];
+	        this.accountId = source["accountId"];
+	        this.arn = source["arn"];
+	        this.message = source["message"];
+	    }
+	}
+	export class RegionInfo {
+	    name: string;
+	    optInStatus: string;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RegionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.optInStatus = source["optInStatus"];
+	        this.enabled = source["enabled"];
+	    }
+	}
+	
+	
+	export class ScanSummary {
+	    id: number;
+	    profile: string;
+	    regions: string[];
+	    scannedAt: string;
+	    instances: number;
+	    stale: number;
+	    amis: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScanSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.profile = source["profile"];
+	        this.regions = source["regions"];
+	        this.scannedAt = source["scannedAt"];
+	        this.instances = source["instances"];
+	        this.stale = source["stale"];
+	        this.amis = source["amis"];
+	    }
+	}
+	export class ScanDiff {
+	    from: ScanSummary;
+	    to: ScanSummary;
+	    added: EC2Instance[];
+	    removed: EC2Instance[];
+	    changed: InstanceChange[];
+	    amiDrift: AMIDrift[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScanDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], ScanSummary);
+	        this.to = this.convertValues(source["to"], ScanSummary);
+	        this.added = this.convertValues(source["added"], EC2Instance);
+	        this.removed = this.convertValues(source["removed"], EC2Instance);
+	        this.changed = this.convertValues(source["changed"], InstanceChange);
+	        this.amiDrift = this.convertValues(source["amiDrift"], AMIDrift);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	
+	
+	export class ServiceResource {
+	    service: string;
+	    type: string;
+	    id: string;
+	    arn: string;
+	    region: string;
+	    version: string;
+	    status: string;
+	    flagged: boolean;
+	    finding?: string;
+	    details?: Record<string, string>;
+	    targets?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ServiceResource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.type = source["type"];
+	        this.id = source["id"];
+	        this.arn = source["arn"];
+	        this.region = source["region"];
+	        this.version = source["version"];
+	        this.status = source["status"];
+	        this.flagged = source["flagged"];
+	        this.finding = source["finding"];
+	        this.details = source["details"];
+	        this.targets = source["targets"];
+	    }
+	}
+	export class SessionStatus {
+	    profile: string;
+	    sso: boolean;
+	    loggedIn: boolean;
+	    valid: boolean;
+	    expiresAt: string;
+	    expiresInMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.sso = source["sso"];
+	        this.loggedIn = source["loggedIn"];
+	        this.valid = source["valid"];
+	        this.expiresAt = source["expiresAt"];
+	        this.expiresInMinutes = source["expiresInMinutes"];
+	    }
+	}
+	
+	export class UserPrefs {
+	    lastProfile: string;
+	    lastFilter: string;
+	    lastRegion: string;
+	    goldenAmis: string[];
+	    goldenAmiParameter: string;
+	    awsConfigFile: string;
+	    awsCredentialsFile: string;
+	    cacheTtlMinutes: number;
+	    policyFile: string;
+	    regoPolicyPath: string;
+	    slackWebhookUrl: string;
+	    emailProfile: string;
+	    emailFrom: string;
+	    emailTo: string[];
+	    snsTopicArn: string;
+	    snsProfile: string;
+	    snsViolationsOnly: boolean;
+	    logLevel: string;
+	    retryMode: string;
+	    retryMaxAttempts: number;
+	    retryMaxBackoffSeconds: number;
+	    operationTimeoutSeconds: number;
+	    operationTimeouts: Record<string, number>;
+	    maxRequestsPerSecond: number;
+	    demoMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserPrefs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lastProfile = source["lastProfile"];
+	        this.lastFilter = source["lastFilter"];
+	        this.lastRegion = source["lastRegion"];
+	        this.goldenAmis = source["goldenAmis"];
+	        this.goldenAmiParameter = source["goldenAmiParameter"];
+	        this.awsConfigFile = source["awsConfigFile"];
+	        this.awsCredentialsFile = source["awsCredentialsFile"];
+	        this.cacheTtlMinutes = source["cacheTtlMinutes"];
+	        this.policyFile = source["policyFile"];
+	        this.regoPolicyPath = source["regoPolicyPath"];
+	        this.slackWebhookUrl = source["slackWebhookUrl"];
+	        this.emailProfile = source["emailProfile"];
+	        this.emailFrom = source["emailFrom"];
+	        this.emailTo = source["emailTo"];
+	        this.snsTopicArn = source["snsTopicArn"];
+	        this.snsProfile = source["snsProfile"];
+	        this.snsViolationsOnly = source["snsViolationsOnly"];
+	        this.logLevel = source["logLevel"];
+	        this.retryMode = source["retryMode"];
+	        this.retryMaxAttempts = source["retryMaxAttempts"];
+	        this.retryMaxBackoffSeconds = source["retryMaxBackoffSeconds"];
+	        this.operationTimeoutSeconds = source["operationTimeoutSeconds"];
+	        this.operationTimeouts = source["operationTimeouts"];
+	        this.maxRequestsPerSecond = source["maxRequestsPerSecond"];
+	        this.demoMode = source["demoMode"];
+	    }
+	}
+	
+
+}
+
+export namespace policy {
+	
+	export class Result {
+	    rule: string;
+	    severity: string;
+	    instanceId: string;
+	    region: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.severity = source["severity"];
+	        this.instanceId = source["instanceId"];
+	        this.region = source["region"];
+	        this.message = source["message"];
+	    }
+	}
 
 }
 
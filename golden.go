@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ComplianceSummary counts instances against the golden AMI allow-list
+type ComplianceSummary struct {
+	GoldenAMIs   int `json:"goldenAmis"`
+	Compliant    int `json:"compliant"`
+	NonCompliant int `json:"nonCompliant"`
+}
+
+// goldenAMIs merges the AMIs listed in the request with the ones stored in the
+// golden AMI SSM parameter (a String or StringList of AMI IDs)
+func (a *App) goldenAMIs(ctx context.Context, cfg aws.Config, req ProcessingRequest) (map[string]bool, error) {
+	golden := make(map[string]bool)
+	for _, id := range req.GoldenAMIs {
+		if id = strings.TrimSpace(id); id != "" {
+			golden[id] = true
+		}
+	}
+
+	if req.GoldenAMIParameter != "" {
+		out, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+			Name: aws.String(req.GoldenAMIParameter),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read golden AMI parameter %s: %w", req.GoldenAMIParameter, err)
+		}
+		for _, id := range strings.FieldsFunc(aws.ToString(out.Parameter.Value), func(r rune) bool {
+			return r == ',' || r == '\n' || r == ' '
+		}) {
+			golden[id] = true
+		}
+	}
+	return golden, nil
+}
+
+// applyGoldenAMIs flags the instances whose AMI is not on the allow-list
+func applyGoldenAMIs(result *AWSResult, golden map[string]bool) {
+	summary := &ComplianceSummary{GoldenAMIs: len(golden)}
+	for i := range result.Instances {
+		inst := &result.Instances[i]
+		inst.NonCompliant = !golden[inst.AMI]
+		if inst.NonCompliant {
+			summary.NonCompliant++
+		} else {
+			summary.Compliant++
+		}
+	}
+	result.Compliance = summary
+}
@@ -0,0 +1,78 @@
+package main
+
+import "sort"
+
+// unknownAMI labels instances whose AMI could not be determined
+const unknownAMI = "unknown"
+
+// AMIGroup is the set of instances running the same AMI
+type AMIGroup struct {
+	AMI        string        `json:"ami"`
+	Count      int           `json:"count"`
+	Deprecated bool          `json:"deprecated"`
+	Instances  []EC2Instance `json:"instances"`
+	// Regions and Accounts count the instances of the group per region and per account
+	Regions  map[string]int `json:"regions"`
+	Accounts map[string]int `json:"accounts"`
+}
+
+// distinctAMIs returns the AMI IDs used by the instances, without duplicates or blanks
+func distinctAMIs(instances []EC2Instance) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, inst := range instances {
+		if inst.AMI == "" || seen[inst.AMI] {
+			continue
+		}
+		seen[inst.AMI] = true
+		ids = append(ids, inst.AMI)
+	}
+	return ids
+}
+
+// GroupInstancesByAMI groups the instances of a result by AMI, largest group first.
+// Instances without an AMI end up in the "unknown" group.
+func GroupInstancesByAMI(result *AWSResult) []AMIGroup {
+	if result == nil {
+		return nil
+	}
+
+	index := make(map[string]int)
+	var groups []AMIGroup
+	for _, inst := range result.Instances {
+		ami := inst.AMI
+		if ami == "" {
+			ami = unknownAMI
+		}
+		i, ok := index[ami]
+		if !ok {
+			i = len(groups)
+			index[ami] = i
+			groups = append(groups, AMIGroup{AMI: ami, Regions: map[string]int{}, Accounts: map[string]int{}})
+		}
+		groups[i].Count++
+		if inst.Region != "" {
+			groups[i].Regions[inst.Region]++
+		}
+		if inst.AccountID != "" {
+			groups[i].Accounts[inst.AccountID]++
+		}
+		groups[i].Instances = append(groups[i].Instances, inst)
+		if inst.AMIDeprecated {
+			groups[i].Deprecated = true
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].AMI < groups[j].AMI
+	})
+	return groups
+}
+
+// GroupByAMI groups the instances of a scan result by AMI, showing which AMIs are deployed where
+func (a *App) GroupByAMI(result *AWSResult) []AMIGroup {
+	return GroupInstancesByAMI(result)
+}
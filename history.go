@@ -0,0 +1,449 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// historySchema creates the tables of the scan history. A scan keeps its result
+// without the instances, stored one row each so that they can be counted and
+// compared by AMI, staleness and account without decoding every result.
+const historySchema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	profile    TEXT NOT NULL,
+	regions    TEXT NOT NULL,
+	scanned_at TEXT NOT NULL,
+	result     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS scans_profile ON scans (profile, regions);
+CREATE TABLE IF NOT EXISTS instances (
+	scan_id     INTEGER NOT NULL REFERENCES scans (id) ON DELETE CASCADE,
+	position    INTEGER NOT NULL,
+	account_id  TEXT NOT NULL,
+	region      TEXT NOT NULL,
+	instance_id TEXT NOT NULL,
+	ami         TEXT NOT NULL,
+	ami_stale   INTEGER NOT NULL,
+	data        TEXT NOT NULL,
+	PRIMARY KEY (scan_id, position)
+);
+CREATE INDEX IF NOT EXISTS instances_ami ON instances (ami, scan_id);
+`
+
+// maxStoredScans bounds the history; the oldest scans are dropped beyond it
+const maxStoredScans = 500
+
+// diffFields are the instance columns compared by DiffScans
+var diffFields = []string{"state", "instanceType", "ami", "amiStatus", "amiStale", "nonCompliant", "imdsV1Allowed", "iamFinding"}
+
+// storedScan is a scan of the history with its instances
+type storedScan struct {
+	ID        uint64
+	Profile   string
+	Regions   []string
+	ScannedAt time.Time
+	Result    *AWSResult
+}
+
+// ScanSummary describes a scan of the history without its rows
+type ScanSummary struct {
+	ID        uint64   `json:"id"`
+	Profile   string   `json:"profile"`
+	Regions   []string `json:"regions"`
+	ScannedAt string   `json:"scannedAt"`
+	Instances int      `json:"instances"`
+	Stale     int      `json:"stale"`
+	AMIs      int      `json:"amis"`
+}
+
+// FieldChange is a field of an instance that differs between two scans
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// InstanceChange lists the changed fields of an instance present in both scans
+type InstanceChange struct {
+	InstanceID string        `json:"instanceId"`
+	Name       string        `json:"name"`
+	Region     string        `json:"region"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+// AMIDrift is the change in the number of instances running an AMI
+type AMIDrift struct {
+	AMI  string `json:"ami"`
+	Name string `json:"name"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Parameter change kinds
+const (
+	ParameterAdded    = "added"
+	ParameterRemoved  = "removed"
+	ParameterModified = "modified"
+)
+
+// ParameterVersionChange is a parameter added, removed or given a new version between two scans
+type ParameterVersionChange struct {
+	Name        string `json:"name"`
+	Region      string `json:"region"`
+	Change      string `json:"change"`
+	FromVersion int64  `json:"fromVersion"`
+	ToVersion   int64  `json:"toVersion"`
+}
+
+// ScanDiff is what changed from scan From to scan To
+type ScanDiff struct {
+	From     ScanSummary      `json:"from"`
+	To       ScanSummary      `json:"to"`
+	Added    []EC2Instance    `json:"added"`
+	Removed  []EC2Instance    `json:"removed"`
+	Changed  []InstanceChange `json:"changed"`
+	AMIDrift []AMIDrift       `json:"amiDrift"`
+}
+
+func (s storedScan) summary() ScanSummary {
+	sum := ScanSummary{ID: s.ID, Profile: s.Profile, Regions: s.Regions, ScannedAt: s.ScannedAt.UTC().Format(time.RFC3339)}
+	if s.Result != nil {
+		sum.Instances = len(s.Result.Instances)
+		sum.AMIs = len(distinctAMIs(s.Result.Instances))
+		for _, inst := range s.Result.Instances {
+			if inst.AMIStale {
+				sum.Stale++
+			}
+		}
+	}
+	return sum
+}
+
+// joinRegions and splitRegions store the regions of a scan as one column;
+// region names have no commas
+func joinRegions(regions []string) string {
+	return strings.Join(regions, ",")
+}
+
+func splitRegions(regions string) []string {
+	if regions == "" {
+		return nil
+	}
+	return strings.Split(regions, ",")
+}
+
+// instanceAccount is the account of an instance, the one of the result for the
+// results scanned before instances had one
+func instanceAccount(r *AWSResult, inst EC2Instance) string {
+	if inst.AccountID == "" && r.Identity != nil {
+		return r.Identity.AccountID
+	}
+	return inst.AccountID
+}
+
+// recordScan adds a scan to the history; failures are logged, the scan itself succeeded
+func (a *App) recordScan(req ProcessingRequest, result *AWSResult) {
+	if err := a.insertScan(req.Profile, req.Regions, time.Now(), result); err != nil {
+		slog.Warn("Unable to record the scan", "err", err)
+	}
+}
+
+// insertScan stores a scan and its instances, then drops the scans beyond maxStoredScans
+func (a *App) insertScan(profile string, regions []string, scannedAt time.Time, result *AWSResult) error {
+	db, err := a.historyDB()
+	if err != nil {
+		return err
+	}
+	withoutInstances := *result
+	withoutInstances.Instances = nil
+	data, err := json.Marshal(withoutInstances)
+	if err != nil {
+		return fmt.Errorf("failed to encode scan: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(`INSERT INTO scans (profile, regions, scanned_at, result) VALUES (?, ?, ?, ?)`,
+		profile, joinRegions(regions), scannedAt.UTC().Format(time.RFC3339Nano), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to insert scan: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO instances (scan_id, position, account_id, region, instance_id, ami, ami_stale, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, inst := range result.Instances {
+		data, err := json.Marshal(inst)
+		if err != nil {
+			return fmt.Errorf("failed to encode instance %s: %w", inst.InstanceID, err)
+		}
+		if _, err := stmt.Exec(id, i, instanceAccount(result, inst), inst.Region, inst.InstanceID, inst.AMI, inst.AMIStale, string(data)); err != nil {
+			return fmt.Errorf("failed to insert instance %s: %w", inst.InstanceID, err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM scans WHERE id NOT IN (SELECT id FROM scans ORDER BY id DESC LIMIT ?)`, maxStoredScans); err != nil {
+		return fmt.Errorf("failed to drop old scans: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ListScans returns the scans of the history, newest first; an empty profile lists every profile
+func (a *App) ListScans(profile string) ([]ScanSummary, error) {
+	db, err := a.historyDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.profile, s.regions, s.scanned_at,
+			COUNT(i.scan_id), COALESCE(SUM(i.ami_stale), 0), COUNT(DISTINCT NULLIF(i.ami, ''))
+		FROM scans s LEFT JOIN instances i ON i.scan_id = s.id
+		WHERE ? = '' OR s.profile = ?
+		GROUP BY s.id
+		ORDER BY s.id DESC`, profile, profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scans: %w", err)
+	}
+	defer rows.Close()
+
+	scans := []ScanSummary{}
+	for rows.Next() {
+		var sum ScanSummary
+		var regions, scannedAt string
+		if err := rows.Scan(&sum.ID, &sum.Profile, &regions, &scannedAt, &sum.Instances, &sum.Stale, &sum.AMIs); err != nil {
+			return nil, fmt.Errorf("failed to list scans: %w", err)
+		}
+		sum.Regions = splitRegions(regions)
+		if t, err := time.Parse(time.RFC3339Nano, scannedAt); err == nil {
+			sum.ScannedAt = t.UTC().Format(time.RFC3339)
+		}
+		scans = append(scans, sum)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list scans: %w", err)
+	}
+	return scans, nil
+}
+
+// GetScan returns the result of a scan of the history
+func (a *App) GetScan(id uint64) (*AWSResult, error) {
+	scan, err := a.loadScan(id)
+	if err != nil {
+		return nil, err
+	}
+	return scan.Result, nil
+}
+
+// DeleteScan removes a scan and its instances from the history
+func (a *App) DeleteScan(id uint64) error {
+	db, err := a.historyDB()
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(`DELETE FROM scans WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete scan %d: %w", id, err)
+	}
+	return nil
+}
+
+// loadScan reads a scan and its instances, in the order of the scan
+func (a *App) loadScan(id uint64) (*storedScan, error) {
+	db, err := a.historyDB()
+	if err != nil {
+		return nil, err
+	}
+	scan := &storedScan{ID: id}
+	var regions, scannedAt, data string
+	err = db.QueryRow(`SELECT profile, regions, scanned_at, result FROM scans WHERE id = ?`, id).
+		Scan(&scan.Profile, &regions, &scannedAt, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("scan %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan %d: %w", id, err)
+	}
+	scan.Regions = splitRegions(regions)
+	scan.ScannedAt, _ = time.Parse(time.RFC3339Nano, scannedAt)
+	if err := json.Unmarshal([]byte(data), &scan.Result); err != nil {
+		return nil, fmt.Errorf("failed to decode scan %d: %w", id, err)
+	}
+
+	rows, err := db.Query(`SELECT data FROM instances WHERE scan_id = ? ORDER BY position`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the instances of scan %d: %w", id, err)
+	}
+	defer rows.Close()
+	scan.Result.Instances = []EC2Instance{}
+	for rows.Next() {
+		var inst EC2Instance
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read the instances of scan %d: %w", id, err)
+		}
+		if err := json.Unmarshal([]byte(data), &inst); err != nil {
+			return nil, fmt.Errorf("failed to decode the instances of scan %d: %w", id, err)
+		}
+		scan.Result.Instances = append(scan.Result.Instances, inst)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the instances of scan %d: %w", id, err)
+	}
+	return scan, nil
+}
+
+// latestScan returns the newest scan of the profile over the same regions, nil if none
+func (a *App) latestScan(profile string, regions []string) *storedScan {
+	db, err := a.historyDB()
+	if err != nil {
+		slog.Warn("Unable to read the scan history", "err", err)
+		return nil
+	}
+	var id uint64
+	err = db.QueryRow(`SELECT id FROM scans WHERE profile = ? AND regions = ? ORDER BY id DESC LIMIT 1`,
+		profile, joinRegions(regions)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Warn("Unable to read the scan history", "err", err)
+		return nil
+	}
+	scan, err := a.loadScan(id)
+	if err != nil {
+		slog.Warn("Unable to read the scan history", "err", err)
+		return nil
+	}
+	return scan
+}
+
+// DiffScans compares two scans of the history: the instances added, removed or
+// changed from scanA to scanB, and how many instances run each AMI in both
+func (a *App) DiffScans(scanA, scanB uint64) (*ScanDiff, error) {
+	from, err := a.loadScan(scanA)
+	if err != nil {
+		return nil, err
+	}
+	to, err := a.loadScan(scanB)
+	if err != nil {
+		return nil, err
+	}
+	diff := diffResults(from.Result, to.Result)
+	diff.From, diff.To = from.summary(), to.summary()
+	return diff, nil
+}
+
+// parameterChanges compares the parameters of two results, matched by region and name
+func parameterChanges(before, after *AWSResult) []ParameterVersionChange {
+	key := func(p ParameterInfo) string { return p.Region + ":" + p.Name }
+	was := make(map[string]ParameterInfo, len(before.Parameters))
+	for _, p := range before.Parameters {
+		was[key(p)] = p
+	}
+	changes := []ParameterVersionChange{}
+	seen := make(map[string]bool, len(after.Parameters))
+	for _, p := range after.Parameters {
+		seen[key(p)] = true
+		old, ok := was[key(p)]
+		switch {
+		case !ok:
+			changes = append(changes, ParameterVersionChange{Name: p.Name, Region: p.Region, Change: ParameterAdded, ToVersion: p.Version})
+		case old.Version != p.Version:
+			changes = append(changes, ParameterVersionChange{Name: p.Name, Region: p.Region, Change: ParameterModified, FromVersion: old.Version, ToVersion: p.Version})
+		}
+	}
+	for _, p := range before.Parameters {
+		if !seen[key(p)] {
+			changes = append(changes, ParameterVersionChange{Name: p.Name, Region: p.Region, Change: ParameterRemoved, FromVersion: p.Version})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Region != changes[j].Region {
+			return changes[i].Region < changes[j].Region
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// diffResults compares the instances of two results, matched by account, region and ID
+func diffResults(from, to *AWSResult) *ScanDiff {
+	fields, _ := selectColumns(instanceColumns, diffFields)
+	key := func(r *AWSResult, inst EC2Instance) string {
+		return instanceAccount(r, inst) + "/" + inst.Region + "/" + inst.InstanceID
+	}
+
+	diff := &ScanDiff{Added: []EC2Instance{}, Removed: []EC2Instance{}, Changed: []InstanceChange{}, AMIDrift: []AMIDrift{}}
+	before := make(map[string]EC2Instance, len(from.Instances))
+	for _, inst := range from.Instances {
+		before[key(from, inst)] = inst
+	}
+	seen := make(map[string]bool, len(to.Instances))
+	for _, inst := range to.Instances {
+		k := key(to, inst)
+		seen[k] = true
+		old, ok := before[k]
+		if !ok {
+			diff.Added = append(diff.Added, inst)
+			continue
+		}
+		change := InstanceChange{InstanceID: inst.InstanceID, Name: inst.Name, Region: inst.Region}
+		for _, f := range fields {
+			if was, is := f.value(old), f.value(inst); was != is {
+				change.Changes = append(change.Changes, FieldChange{Field: f.name, From: was, To: is})
+			}
+		}
+		if len(change.Changes) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	for _, inst := range from.Instances {
+		if !seen[key(from, inst)] {
+			diff.Removed = append(diff.Removed, inst)
+		}
+	}
+
+	counts := map[string]*AMIDrift{}
+	count := func(instances []EC2Instance, isFrom bool) {
+		for _, inst := range instances {
+			ami := inst.AMI
+			if ami == "" {
+				ami = unknownAMI
+			}
+			d, ok := counts[ami]
+			if !ok {
+				d = &AMIDrift{AMI: ami}
+				counts[ami] = d
+			}
+			if inst.AMIName != "" {
+				d.Name = inst.AMIName
+			}
+			if isFrom {
+				d.From++
+			} else {
+				d.To++
+			}
+		}
+	}
+	count(from.Instances, true)
+	count(to.Instances, false)
+	for _, d := range counts {
+		if d.From != d.To {
+			diff.AMIDrift = append(diff.AMIDrift, *d)
+		}
+	}
+	sort.Slice(diff.AMIDrift, func(i, j int) bool { return diff.AMIDrift[i].AMI < diff.AMIDrift[j].AMI })
+	return diff
+}
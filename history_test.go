@@ -0,0 +1,118 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// newHistoryApp returns an app whose history lives in a temporary config dir
+func newHistoryApp(t *testing.T) *App {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	a := NewApp()
+	t.Cleanup(a.closeStore)
+	return a
+}
+
+func TestDiffScans(t *testing.T) {
+	a := newHistoryApp(t)
+	req := ProcessingRequest{Profile: "prod", Regions: []string{"us-east-1"}}
+
+	a.recordScan(req, &AWSResult{
+		Identity: &CallerIdentity{AccountID: "111111111111"},
+		Instances: []EC2Instance{
+			{InstanceID: "i-kept", Region: "us-east-1", State: "running", AMI: "ami-old", AMIName: "base-v1"},
+			{InstanceID: "i-gone", Region: "us-east-1", State: "running", AMI: "ami-old", AMIName: "base-v1"},
+		},
+	})
+	a.recordScan(req, &AWSResult{
+		Identity: &CallerIdentity{AccountID: "111111111111"},
+		Instances: []EC2Instance{
+			{InstanceID: "i-kept", Region: "us-east-1", State: "stopped", AMI: "ami-new", AMIName: "base-v2", AMIStale: true},
+			{InstanceID: "i-new", Region: "us-east-1", State: "running", AMI: "ami-new", AMIName: "base-v2"},
+		},
+	})
+
+	scans, err := a.ListScans("prod")
+	if err != nil {
+		t.Fatalf("ListScans: %v", err)
+	}
+	if len(scans) != 2 {
+		t.Fatalf("ListScans returned %d scans, want 2", len(scans))
+	}
+	newest, oldest := scans[0], scans[1]
+	if newest.ID <= oldest.ID {
+		t.Fatalf("ListScans is not newest first: %d then %d", newest.ID, oldest.ID)
+	}
+	if newest.Instances != 2 || newest.Stale != 1 || newest.AMIs != 1 {
+		t.Errorf("newest summary = %+v, want 2 instances, 1 stale, 1 AMI", newest)
+	}
+	if !slices.Equal(newest.Regions, req.Regions) {
+		t.Errorf("newest regions = %v, want %v", newest.Regions, req.Regions)
+	}
+
+	diff, err := a.DiffScans(oldest.ID, newest.ID)
+	if err != nil {
+		t.Fatalf("DiffScans: %v", err)
+	}
+	if diff.From.ID != oldest.ID || diff.To.ID != newest.ID {
+		t.Errorf("diff is from %d to %d, want %d to %d", diff.From.ID, diff.To.ID, oldest.ID, newest.ID)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].InstanceID != "i-new" {
+		t.Errorf("added = %+v, want i-new", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].InstanceID != "i-gone" {
+		t.Errorf("removed = %+v, want i-gone", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].InstanceID != "i-kept" {
+		t.Fatalf("changed = %+v, want i-kept", diff.Changed)
+	}
+	want := []FieldChange{
+		{Field: "state", From: "running", To: "stopped"},
+		{Field: "ami", From: "ami-old", To: "ami-new"},
+		{Field: "amiStale", From: "false", To: "true"},
+	}
+	if got := diff.Changed[0].Changes; !slices.Equal(got, want) {
+		t.Errorf("changes of i-kept = %+v, want %+v", got, want)
+	}
+	wantDrift := []AMIDrift{
+		{AMI: "ami-new", Name: "base-v2", From: 0, To: 2},
+		{AMI: "ami-old", Name: "base-v1", From: 2, To: 0},
+	}
+	if !slices.Equal(diff.AMIDrift, wantDrift) {
+		t.Errorf("AMI drift = %+v, want %+v", diff.AMIDrift, wantDrift)
+	}
+}
+
+func TestDiffScansUnknownScan(t *testing.T) {
+	a := newHistoryApp(t)
+	a.recordScan(ProcessingRequest{Profile: "prod"}, &AWSResult{})
+	scans, err := a.ListScans("")
+	if err != nil || len(scans) != 1 {
+		t.Fatalf("ListScans = %v, %v, want one scan", scans, err)
+	}
+	if _, err := a.DiffScans(scans[0].ID, scans[0].ID+1); err == nil {
+		t.Error("DiffScans with an unknown scan succeeded")
+	}
+}
+
+func TestRecordScanKeepsLatest(t *testing.T) {
+	a := newHistoryApp(t)
+	for range maxStoredScans + 3 {
+		a.recordScan(ProcessingRequest{Profile: "prod"}, &AWSResult{Instances: []EC2Instance{{InstanceID: "i-1", AMI: "ami-1"}}})
+	}
+	scans, err := a.ListScans("prod")
+	if err != nil {
+		t.Fatalf("ListScans: %v", err)
+	}
+	if len(scans) != maxStoredScans {
+		t.Fatalf("history holds %d scans, want %d", len(scans), maxStoredScans)
+	}
+	if _, err := a.GetScan(scans[len(scans)-1].ID - 1); err == nil {
+		t.Error("the oldest scans were not dropped")
+	}
+	if latest := a.latestScan("prod", nil); latest == nil || latest.ID != scans[0].ID || len(latest.Result.Instances) != 1 {
+		t.Errorf("latestScan = %+v, want scan %d with its instance", latest, scans[0].ID)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+// IAM findings reported per instance
+const (
+	IAMFindingNoRole    = "no role"
+	IAMFindingBroadRole = "broad role"
+)
+
+// broadManagedPolicies are the AWS managed policies that grant far more than a workload needs
+var broadManagedPolicies = map[string]bool{
+	"arn:aws:iam::aws:policy/AdministratorAccess": true,
+	"arn:aws:iam::aws:policy/PowerUserAccess":     true,
+	"arn:aws:iam::aws:policy/IAMFullAccess":       true,
+}
+
+// instanceRole is the role behind an instance profile and its broad policies
+type instanceRole struct {
+	name          string
+	broadPolicies []string
+}
+
+// resolveInstanceRoles fills the role of each instance from its instance profile and
+// flags the instances without a role or whose role has a broad managed policy attached.
+// Only managed policies are inspected; inline policies are not.
+func resolveInstanceRoles(ctx context.Context, cfg aws.Config, instances []EC2Instance) error {
+	iamClient := iam.NewFromConfig(cfg)
+	roles := make(map[string]*instanceRole)
+
+	for i := range instances {
+		inst := &instances[i]
+		if inst.InstanceProfileARN == "" {
+			inst.IAMFinding = IAMFindingNoRole
+			continue
+		}
+
+		role, ok := roles[inst.InstanceProfileARN]
+		if !ok {
+			var err error
+			role, err = describeInstanceRole(ctx, iamClient, inst.InstanceProfileARN)
+			if err != nil {
+				return err
+			}
+			roles[inst.InstanceProfileARN] = role
+		}
+
+		switch {
+		case role == nil:
+			inst.IAMFinding = IAMFindingNoRole
+		case len(role.broadPolicies) > 0:
+			inst.IAMRole = role.name
+			inst.IAMFinding = IAMFindingBroadRole + ": " + strings.Join(role.broadPolicies, ", ")
+		default:
+			inst.IAMRole = role.name
+		}
+	}
+	return nil
+}
+
+// describeInstanceRole returns the role of an instance profile, nil if it has none
+func describeInstanceRole(ctx context.Context, client *iam.Client, profileARN string) (*instanceRole, error) {
+	// arn:aws:iam::123456789012:instance-profile/path/name -> name
+	name := profileARN[strings.LastIndex(profileARN, "/")+1:]
+
+	out, err := client.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance profile %s: %w", name, err)
+	}
+	if len(out.InstanceProfile.Roles) == 0 {
+		return nil, nil
+	}
+
+	role := &instanceRole{name: aws.ToString(out.InstanceProfile.Roles[0].RoleName)}
+	pager := iam.NewListAttachedRolePoliciesPaginator(client, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(role.name)})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies of role %s: %w", role.name, err)
+		}
+		for _, p := range page.AttachedPolicies {
+			if broadManagedPolicies[aws.ToString(p.PolicyArn)] {
+				role.broadPolicies = append(role.broadPolicies, aws.ToString(p.PolicyName))
+			}
+		}
+	}
+	return role, nil
+}
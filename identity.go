@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// CallerIdentity is the principal a scan ran as, so users can confirm the account
+type CallerIdentity struct {
+	AccountID string `json:"accountId"`
+	ARN       string `json:"arn"`
+	UserID    string `json:"userId"`
+	// AccountAlias is empty when the account has none or iam:ListAccountAliases is denied
+	AccountAlias string `json:"accountAlias,omitempty"`
+}
+
+// callerIdentity completes the STS identity with the account alias
+func (a *App) callerIdentity(ctx context.Context, cfg aws.Config, out *sts.GetCallerIdentityOutput) *CallerIdentity {
+	identity := &CallerIdentity{
+		AccountID: aws.ToString(out.Account),
+		ARN:       aws.ToString(out.Arn),
+		UserID:    aws.ToString(out.UserId),
+	}
+
+	aliases, err := iam.NewFromConfig(cfg).ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+	if err != nil {
+		slog.Warn("Unable to resolve account alias", "account", identity.AccountID, "err", err)
+		return identity
+	}
+	// An account has at most one alias
+	if len(aliases.AccountAliases) > 0 {
+		identity.AccountAlias = aliases.AccountAliases[0]
+	}
+	return identity
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// defaultSSMKeyAlias is the AWS managed key SecureStrings use when no key is given
+const defaultSSMKeyAlias = "alias/aws/ssm"
+
+// SecureStringKey is the KMS key encrypting one SecureString parameter
+type SecureStringKey struct {
+	Name  string `json:"name"`
+	KeyID string `json:"keyId"`
+	// DefaultKey is set when the parameter uses the AWS managed aws/ssm key
+	DefaultKey bool `json:"defaultKey"`
+	// Flagged is set when the policy requires a customer managed key and DefaultKey is set
+	Flagged bool `json:"flagged"`
+}
+
+// KMSKeyAudit lists the SecureString parameters with their KMS keys
+type KMSKeyAudit struct {
+	Parameters []SecureStringKey `json:"parameters"`
+	// Keys counts the parameters per KMS key
+	Keys         map[string]int `json:"keys"`
+	DefaultCount int            `json:"defaultCount"`
+	FlaggedCount int            `json:"flaggedCount"`
+}
+
+// AuditSecureStringKeys reports the KMS key of each SecureString parameter matching filter.
+// With requireCustomerKey, parameters encrypted with the default aws/ssm key are flagged.
+func (a *App) AuditSecureStringKeys(profile string, filter SSMFilter, requireCustomerKey bool) (*KMSKeyAudit, error) {
+	filter.Types = []string{string(ssmtypes.ParameterTypeSecureString)}
+	filters, err := buildParameterFilters(filter)
+	if err != nil {
+		return nil, err
+	}
+	match, err := filter.nameMatcher()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &KMSKeyAudit{Parameters: []SecureStringKey{}, Keys: make(map[string]int)}
+	// GetParametersByPath doesn't return the key, so this always uses DescribeParameters
+	paginator := ssm.NewDescribeParametersPaginator(ssm.NewFromConfig(cfg), &ssm.DescribeParametersInput{
+		ParameterFilters: filters,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(a.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list params: %w", err)
+		}
+		for _, p := range page.Parameters {
+			if match != nil && !match(aws.ToString(p.Name)) {
+				continue
+			}
+			key := SecureStringKey{Name: aws.ToString(p.Name), KeyID: aws.ToString(p.KeyId)}
+			key.DefaultKey = isDefaultSSMKey(key.KeyID)
+			key.Flagged = requireCustomerKey && key.DefaultKey
+			if key.DefaultKey {
+				audit.DefaultCount++
+			}
+			if key.Flagged {
+				audit.FlaggedCount++
+			}
+			audit.Keys[key.KeyID]++
+			audit.Parameters = append(audit.Parameters, key)
+		}
+	}
+
+	sort.Slice(audit.Parameters, func(i, j int) bool { return audit.Parameters[i].Name < audit.Parameters[j].Name })
+	return audit, nil
+}
+
+// isDefaultSSMKey reports whether keyID refers to the aws/ssm alias, by name or alias ARN.
+// A key ID or key ARN is assumed to be customer managed.
+func isDefaultSSMKey(keyID string) bool {
+	return keyID == "" || keyID == defaultSSMKeyAlias || strings.HasSuffix(keyID, ":"+defaultSSMKeyAlias)
+}
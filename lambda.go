@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// runtimeWarningDays is how long before its deprecation a runtime is flagged
+const runtimeWarningDays = 180
+
+// lambdaRuntimeDeprecations are the dates after which AWS stops patching a Lambda runtime.
+// See https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html
+var lambdaRuntimeDeprecations = map[string]string{
+	"python2.7":     "2021-07-15",
+	"python3.6":     "2022-07-18",
+	"python3.7":     "2023-12-04",
+	"python3.8":     "2024-10-14",
+	"python3.9":     "2025-12-15",
+	"nodejs":        "2016-10-31",
+	"nodejs4.3":     "2020-03-05",
+	"nodejs6.10":    "2019-08-12",
+	"nodejs8.10":    "2020-03-06",
+	"nodejs10.x":    "2021-07-30",
+	"nodejs12.x":    "2023-03-31",
+	"nodejs14.x":    "2023-12-04",
+	"nodejs16.x":    "2024-06-12",
+	"nodejs18.x":    "2025-09-01",
+	"java8":         "2024-01-08",
+	"dotnetcore1.0": "2019-07-30",
+	"dotnetcore2.0": "2019-05-30",
+	"dotnetcore2.1": "2022-01-05",
+	"dotnetcore3.1": "2023-04-03",
+	"dotnet5.0":     "2022-05-10",
+	"dotnet6":       "2024-12-20",
+	"dotnet7":       "2024-05-14",
+	"ruby2.5":       "2021-07-30",
+	"ruby2.7":       "2023-12-07",
+	"ruby3.2":       "2026-03-31",
+	"go1.x":         "2024-01-08",
+	"provided":      "2024-01-08",
+}
+
+// Runtime statuses of LambdaFunction
+const (
+	RuntimeSupported   = "supported"
+	RuntimeDeprecating = "deprecating"
+	RuntimeDeprecated  = "deprecated"
+)
+
+// LambdaFunction is a Lambda function with its runtime support status
+type LambdaFunction struct {
+	Name   string `json:"name"`
+	ARN    string `json:"arn"`
+	Region string `json:"region"`
+	// Runtime is empty for container image functions
+	Runtime       string   `json:"runtime"`
+	PackageType   string   `json:"packageType"`
+	Architectures []string `json:"architectures"`
+	// Layers are the ARNs of the layer versions used by the function
+	Layers       []string `json:"layers"`
+	LastModified string   `json:"lastModified"`
+	// RuntimeStatus is supported, deprecating (within runtimeWarningDays) or deprecated
+	RuntimeStatus   string `json:"runtimeStatus"`
+	DeprecationDate string `json:"deprecationDate,omitempty"`
+}
+
+// listLambdaFunctions returns the functions of region with their runtime status
+func listLambdaFunctions(ctx context.Context, client *lambda.Client, region string) ([]LambdaFunction, error) {
+	functions := []LambdaFunction{}
+	now := time.Now()
+	pager := lambda.NewListFunctionsPaginator(client, &lambda.ListFunctionsInput{MaxItems: aws.Int32(50)})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list lambda functions: %w", err)
+		}
+		for _, f := range page.Functions {
+			fn := LambdaFunction{
+				Name:          aws.ToString(f.FunctionName),
+				ARN:           aws.ToString(f.FunctionArn),
+				Region:        region,
+				Runtime:       string(f.Runtime),
+				PackageType:   string(f.PackageType),
+				Architectures: []string{},
+				Layers:        []string{},
+				LastModified:  aws.ToString(f.LastModified),
+			}
+			for _, arch := range f.Architectures {
+				fn.Architectures = append(fn.Architectures, string(arch))
+			}
+			for _, l := range f.Layers {
+				fn.Layers = append(fn.Layers, aws.ToString(l.Arn))
+			}
+			fn.RuntimeStatus, fn.DeprecationDate = runtimeStatus(fn.Runtime, now)
+			functions = append(functions, fn)
+		}
+	}
+	return functions, nil
+}
+
+// runtimeStatus returns the support status of a Lambda runtime and its deprecation date, if any
+func runtimeStatus(runtime string, now time.Time) (string, string) {
+	date, ok := lambdaRuntimeDeprecations[runtime]
+	if !ok {
+		return RuntimeSupported, ""
+	}
+	deprecated, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return RuntimeSupported, ""
+	}
+	switch {
+	case !now.Before(deprecated):
+		return RuntimeDeprecated, date
+	case deprecated.Sub(now) <= runtimeWarningDays*24*time.Hour:
+		return RuntimeDeprecating, date
+	default:
+		return RuntimeSupported, date
+	}
+}
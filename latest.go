@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// publicAMIFamily maps the name of an official AMI to the public SSM
+// parameter that always holds the latest image of the same family
+type publicAMIFamily struct {
+	pattern   *regexp.Regexp
+	parameter func(m []string) string
+}
+
+var publicAMIFamilies = []publicAMIFamily{
+	{
+		// al2023-ami-2023.4.20240416.0-kernel-6.1-x86_64, al2023-ami-minimal-...
+		pattern: regexp.MustCompile(`^al2023-ami-(minimal-)?2023\.[0-9.]+-kernel-[0-9.]+-(x86_64|arm64)$`),
+		parameter: func(m []string) string {
+			return "/aws/service/ami-amazon-linux-latest/al2023-ami-" + m[1] + "kernel-default-" + m[2]
+		},
+	},
+	{
+		// amzn2-ami-hvm-2.0.20240412.0-x86_64-gp2, amzn2-ami-kernel-5.10-hvm-...
+		pattern: regexp.MustCompile(`^amzn2-ami-(kernel-5\.10-)?hvm-2\.0\.[0-9.]+-(x86_64|arm64)-gp2$`),
+		parameter: func(m []string) string {
+			return "/aws/service/ami-amazon-linux-latest/amzn2-ami-" + m[1] + "hvm-" + m[2] + "-gp2"
+		},
+	},
+	{
+		// ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-20240301
+		pattern: regexp.MustCompile(`^ubuntu/images/hvm-ssd(-gp3)?/ubuntu-[a-z]+-([0-9.]+)-(amd64|arm64)-server-[0-9.]+$`),
+		parameter: func(m []string) string {
+			volume := "ebs-gp2"
+			if m[1] != "" {
+				volume = "ebs-gp3"
+			}
+			return "/aws/service/canonical/ubuntu/server/" + m[2] + "/stable/current/" + m[3] + "/hvm/" + volume + "/ami-id"
+		},
+	},
+	{
+		// Windows_Server-2022-English-Full-Base-2024.04.10
+		pattern: regexp.MustCompile(`^(Windows_Server-[0-9]+-.+)-[0-9]{4}\.[0-9]{2}\.[0-9]{2}$`),
+		parameter: func(m []string) string {
+			return "/aws/service/ami-windows-latest/" + m[1]
+		},
+	},
+}
+
+// latestPublicAMIParameter returns the public SSM parameter for an AMI name, or "" for custom images
+func latestPublicAMIParameter(amiName string) string {
+	for _, family := range publicAMIFamilies {
+		if m := family.pattern.FindStringSubmatch(amiName); m != nil {
+			return family.parameter(m)
+		}
+	}
+	return ""
+}
+
+// getParametersBatchSize is the GetParameters limit
+const getParametersBatchSize = 10
+
+// compareLatestPublicAMIs fills RecommendedAMI and AMIDaysBehind for instances running
+// Amazon Linux, Ubuntu or Windows images, resolving the latest AMI of each family
+// through the public SSM parameters of the region.
+func (a *App) compareLatestPublicAMIs(ctx context.Context, cfg aws.Config, instances []EC2Instance) error {
+	// 1. Which public parameter does each instance map to?
+	paramOf := make(map[int]string)
+	var names []string
+	seen := make(map[string]bool)
+	for i, inst := range instances {
+		param := latestPublicAMIParameter(inst.AMIName)
+		if param == "" {
+			continue
+		}
+		paramOf[i] = param
+		if !seen[param] {
+			seen[param] = true
+			names = append(names, param)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	// 2. Resolve the latest AMI IDs
+	ssmClient := ssm.NewFromConfig(cfg)
+	latest := make(map[string]string)
+	for start := 0; start < len(names); start += getParametersBatchSize {
+		end := min(start+getParametersBatchSize, len(names))
+		out, err := ssmClient.GetParameters(ctx, &ssm.GetParametersInput{Names: names[start:end]})
+		if err != nil {
+			return fmt.Errorf("failed to get latest public AMI parameters: %w", err)
+		}
+		for _, p := range out.Parameters {
+			latest[aws.ToString(p.Name)] = aws.ToString(p.Value)
+		}
+	}
+
+	// 3. Creation dates of the latest AMIs tell how far behind each instance is
+	var latestIDs []string
+	for _, id := range latest {
+		latestIDs = append(latestIDs, id)
+	}
+	images, err := describeAMIs(ctx, ec2.NewFromConfig(cfg), latestIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, param := range paramOf {
+		id, ok := latest[param]
+		if !ok {
+			continue
+		}
+		inst := &instances[i]
+		inst.RecommendedAMI = id
+		if img, ok := images[id]; ok && id != inst.AMI {
+			inst.AMIDaysBehind = daysBetween(inst.AMICreationDate, aws.ToString(img.CreationDate))
+		}
+	}
+	return nil
+}
+
+// daysBetween returns the whole days from one RFC 3339 date to another, 0 if either is invalid
+func daysBetween(from, to string) int {
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return 0
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return 0
+	}
+	return int(t.Sub(f).Hours() / 24)
+}
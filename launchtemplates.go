@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// resolveSSMPrefix starts the image IDs of launch templates that name an SSM parameter
+const resolveSSMPrefix = "resolve:ssm:"
+
+// describeInstancesBatchSize keeps the instance-id filter within the API limits
+const describeInstancesBatchSize = 200
+
+// LaunchTemplateVersionAMI is the AMI a launch template version points to
+type LaunchTemplateVersionAMI struct {
+	TemplateID   string `json:"templateId"`
+	TemplateName string `json:"templateName"`
+	Version      int64  `json:"version"`
+	Default      bool   `json:"default"`
+	Latest       bool   `json:"latest"`
+	// ImageID is an AMI ID or a "resolve:ssm:" reference; empty if the version sets none
+	ImageID    string `json:"imageId"`
+	CreateTime string `json:"createTime"`
+}
+
+// ASGInstanceAMI is an instance of an Auto Scaling group and the AMI it runs
+type ASGInstanceAMI struct {
+	InstanceID      string `json:"instanceId"`
+	AMI             string `json:"ami"`
+	TemplateVersion string `json:"templateVersion"`
+	Drifted         bool   `json:"drifted"`
+}
+
+// ASGDrift compares the AMI configured for an Auto Scaling group with its instances
+type ASGDrift struct {
+	AutoScalingGroup string `json:"autoScalingGroup"`
+	TemplateID       string `json:"templateId"`
+	TemplateName     string `json:"templateName"`
+	// TemplateVersion is the version the group launches, as set on the group:
+	// $Default, $Latest or a number; Version is the number it resolves to
+	TemplateVersion string `json:"templateVersion"`
+	Version         int64  `json:"version"`
+	// ConfiguredAMI is the image ID of that version, an AMI ID or a "resolve:ssm:"
+	// reference, and ResolvedAMI the AMI ID the instances are compared with
+	ConfiguredAMI string `json:"configuredAmi"`
+	ResolvedAMI   string `json:"resolvedAmi"`
+	// Unresolved is set when the SSM reference of ConfiguredAMI could not be read;
+	// the instances are then not compared
+	Unresolved bool `json:"unresolved"`
+	// LatestAMI is the AMI of the template's latest version, when it differs from the configured one
+	LatestAMI    string           `json:"latestAmi"`
+	Instances    []ASGInstanceAMI `json:"instances"`
+	DriftedCount int              `json:"driftedCount"`
+}
+
+// LaunchTemplateAudit is the result of AuditLaunchTemplates
+type LaunchTemplateAudit struct {
+	Region            string                     `json:"region"`
+	Versions          []LaunchTemplateVersionAMI `json:"versions"`
+	AutoScalingGroups []ASGDrift                 `json:"autoScalingGroups"`
+}
+
+// AuditLaunchTemplates reports the AMI of every launch template version of the
+// profile's region and the Auto Scaling groups whose instances run another AMI
+// than the template version the group launches, from its launch template or its
+// mixed instances policy. Groups launched from launch configurations are not listed.
+func (a *App) AuditLaunchTemplates(profile string) (*LaunchTemplateAudit, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	versions, err := launchTemplateVersions(a.ctx, ec2Client)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := autoScalingGroups(a.ctx, autoscaling.NewFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	var instanceIDs []string
+	for _, g := range groups {
+		for _, inst := range g.Instances {
+			instanceIDs = append(instanceIDs, aws.ToString(inst.InstanceId))
+		}
+	}
+	amis, err := instanceAMIs(a.ctx, ec2Client, instanceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := newTemplateVersionIndex(versions)
+	ssmClient := ssm.NewFromConfig(cfg)
+	resolved := make(map[string]string)
+	audit := &LaunchTemplateAudit{Region: cfg.Region, Versions: versions, AutoScalingGroups: []ASGDrift{}}
+	for _, g := range groups {
+		spec := asgLaunchTemplate(g)
+		if spec == nil {
+			continue
+		}
+		drift := ASGDrift{
+			AutoScalingGroup: aws.ToString(g.AutoScalingGroupName),
+			TemplateVersion:  launchTemplateVersionOf(spec),
+			Instances:        []ASGInstanceAMI{},
+		}
+		version, ok := templates.resolve(spec)
+		if ok {
+			drift.TemplateID, drift.TemplateName = version.TemplateID, version.TemplateName
+			drift.Version, drift.ConfiguredAMI = version.Version, version.ImageID
+			if l, ok := templates.latest[version.TemplateID]; ok && l.ImageID != version.ImageID {
+				drift.LatestAMI = l.ImageID
+			}
+		} else {
+			drift.TemplateID, drift.TemplateName = aws.ToString(spec.LaunchTemplateId), aws.ToString(spec.LaunchTemplateName)
+		}
+
+		drift.ResolvedAMI = drift.ConfiguredAMI
+		if strings.HasPrefix(drift.ConfiguredAMI, resolveSSMPrefix) {
+			drift.ResolvedAMI, drift.Unresolved = resolveImageParameter(a.ctx, ssmClient, drift.ConfiguredAMI, resolved)
+		}
+		for _, inst := range g.Instances {
+			entry := ASGInstanceAMI{InstanceID: aws.ToString(inst.InstanceId), AMI: amis[aws.ToString(inst.InstanceId)]}
+			if inst.LaunchTemplate != nil {
+				entry.TemplateVersion = aws.ToString(inst.LaunchTemplate.Version)
+			}
+			// Without a known template AMI there is nothing to compare against
+			if drift.ResolvedAMI != "" && entry.AMI != "" && entry.AMI != drift.ResolvedAMI {
+				entry.Drifted = true
+				drift.DriftedCount++
+			}
+			drift.Instances = append(drift.Instances, entry)
+		}
+		audit.AutoScalingGroups = append(audit.AutoScalingGroups, drift)
+	}
+	sort.Slice(audit.AutoScalingGroups, func(i, j int) bool {
+		return audit.AutoScalingGroups[i].AutoScalingGroup < audit.AutoScalingGroups[j].AutoScalingGroup
+	})
+	return audit, nil
+}
+
+// resolveImageParameter reads the AMI ID of a "resolve:ssm:" reference, caching it in
+// resolved; unresolved is set when the parameter can't be read
+func resolveImageParameter(ctx context.Context, client *ssm.Client, ref string, resolved map[string]string) (ami string, unresolved bool) {
+	if ami, ok := resolved[ref]; ok {
+		return ami, ami == ""
+	}
+	out, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(strings.TrimPrefix(ref, resolveSSMPrefix))})
+	if err != nil {
+		slog.Warn("Unable to resolve the AMI parameter of a launch template", "parameter", ref, "err", err)
+		resolved[ref] = ""
+		return "", true
+	}
+	ami = aws.ToString(out.Parameter.Value)
+	resolved[ref] = ami
+	return ami, ami == ""
+}
+
+// launchTemplateVersions lists every version of every launch template of the region
+func launchTemplateVersions(ctx context.Context, client *ec2.Client) ([]LaunchTemplateVersionAMI, error) {
+	var templates []ec2types.LaunchTemplate
+	ltPager := ec2.NewDescribeLaunchTemplatesPaginator(client, &ec2.DescribeLaunchTemplatesInput{})
+	for ltPager.HasMorePages() {
+		page, err := ltPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe launch templates: %w", err)
+		}
+		templates = append(templates, page.LaunchTemplates...)
+	}
+
+	versions := []LaunchTemplateVersionAMI{}
+	for _, lt := range templates {
+		latest := aws.ToInt64(lt.LatestVersionNumber)
+		pager := ec2.NewDescribeLaunchTemplateVersionsPaginator(client, &ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: lt.LaunchTemplateId,
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe versions of launch template %s: %w", aws.ToString(lt.LaunchTemplateId), err)
+			}
+			for _, v := range page.LaunchTemplateVersions {
+				entry := LaunchTemplateVersionAMI{
+					TemplateID:   aws.ToString(v.LaunchTemplateId),
+					TemplateName: aws.ToString(v.LaunchTemplateName),
+					Version:      aws.ToInt64(v.VersionNumber),
+					Default:      aws.ToBool(v.DefaultVersion),
+				}
+				entry.Latest = entry.Version == latest
+				if v.LaunchTemplateData != nil {
+					entry.ImageID = aws.ToString(v.LaunchTemplateData.ImageId)
+				}
+				if v.CreateTime != nil {
+					entry.CreateTime = v.CreateTime.UTC().Format(time.RFC3339)
+				}
+				versions = append(versions, entry)
+			}
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].TemplateName != versions[j].TemplateName {
+			return versions[i].TemplateName < versions[j].TemplateName
+		}
+		return versions[i].Version > versions[j].Version
+	})
+	return versions, nil
+}
+
+// templateVersionIndex finds the launch template versions referenced by Auto Scaling groups
+type templateVersionIndex struct {
+	ids      map[string]string // template name to ID
+	versions map[string]map[int64]LaunchTemplateVersionAMI
+	defaults map[string]LaunchTemplateVersionAMI
+	latest   map[string]LaunchTemplateVersionAMI
+}
+
+func newTemplateVersionIndex(versions []LaunchTemplateVersionAMI) templateVersionIndex {
+	idx := templateVersionIndex{
+		ids:      make(map[string]string),
+		versions: make(map[string]map[int64]LaunchTemplateVersionAMI),
+		defaults: make(map[string]LaunchTemplateVersionAMI),
+		latest:   make(map[string]LaunchTemplateVersionAMI),
+	}
+	for _, v := range versions {
+		idx.ids[v.TemplateName] = v.TemplateID
+		if idx.versions[v.TemplateID] == nil {
+			idx.versions[v.TemplateID] = make(map[int64]LaunchTemplateVersionAMI)
+		}
+		idx.versions[v.TemplateID][v.Version] = v
+		if v.Default {
+			idx.defaults[v.TemplateID] = v
+		}
+		if v.Latest {
+			idx.latest[v.TemplateID] = v
+		}
+	}
+	return idx
+}
+
+// resolve returns the version a launch template specification points to
+func (idx templateVersionIndex) resolve(spec *autoscalingtypes.LaunchTemplateSpecification) (LaunchTemplateVersionAMI, bool) {
+	id := aws.ToString(spec.LaunchTemplateId)
+	if id == "" {
+		id = idx.ids[aws.ToString(spec.LaunchTemplateName)]
+	}
+	switch version := launchTemplateVersionOf(spec); version {
+	case "$Default":
+		v, ok := idx.defaults[id]
+		return v, ok
+	case "$Latest":
+		v, ok := idx.latest[id]
+		return v, ok
+	default:
+		n, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return LaunchTemplateVersionAMI{}, false
+		}
+		v, ok := idx.versions[id][n]
+		return v, ok
+	}
+}
+
+// launchTemplateVersionOf returns the version of a specification; none means $Default
+func launchTemplateVersionOf(spec *autoscalingtypes.LaunchTemplateSpecification) string {
+	if v := aws.ToString(spec.Version); v != "" {
+		return v
+	}
+	return "$Default"
+}
+
+// asgLaunchTemplate returns the launch template a group launches, from its mixed
+// instances policy if it has one; nil for a launch configuration
+func asgLaunchTemplate(g autoscalingtypes.AutoScalingGroup) *autoscalingtypes.LaunchTemplateSpecification {
+	if g.LaunchTemplate != nil {
+		return g.LaunchTemplate
+	}
+	if p := g.MixedInstancesPolicy; p != nil && p.LaunchTemplate != nil {
+		return p.LaunchTemplate.LaunchTemplateSpecification
+	}
+	return nil
+}
+
+// autoScalingGroups lists the Auto Scaling groups of the region
+func autoScalingGroups(ctx context.Context, client *autoscaling.Client) ([]autoscalingtypes.AutoScalingGroup, error) {
+	var groups []autoscalingtypes.AutoScalingGroup
+	pager := autoscaling.NewDescribeAutoScalingGroupsPaginator(client, &autoscaling.DescribeAutoScalingGroupsInput{})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe Auto Scaling groups: %w", err)
+		}
+		groups = append(groups, page.AutoScalingGroups...)
+	}
+	return groups, nil
+}
+
+// instanceAMIs returns the AMI of each instance, keyed by instance ID. A filter is
+// used so that instances terminated meanwhile are skipped instead of failing the call.
+func instanceAMIs(ctx context.Context, client *ec2.Client, ids []string) (map[string]string, error) {
+	amis := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += describeInstancesBatchSize {
+		end := min(start+describeInstancesBatchSize, len(ids))
+		pager := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("instance-id"), Values: ids[start:end]},
+			},
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe Auto Scaling instances: %w", err)
+			}
+			for _, res := range page.Reservations {
+				for _, inst := range res.Instances {
+					amis[aws.ToString(inst.InstanceId)] = aws.ToString(inst.ImageId)
+				}
+			}
+		}
+	}
+	return amis, nil
+}
+
+// actionUpdateLaunchTemplate is the confirmation action of UpdateLaunchTemplateAMI
+const actionUpdateLaunchTemplate = "update-launch-template"
+
+// LaunchTemplateUpdateResult reports the version created by UpdateLaunchTemplateAMI
+type LaunchTemplateUpdateResult struct {
+	TemplateID string `json:"templateId"`
+	AMI        string `json:"ami"`
+	DryRun     bool   `json:"dryRun"`
+	// Version is the new version number, 0 for a dry run
+	Version    int64  `json:"version"`
+	SetDefault bool   `json:"setDefault"`
+	Message    string `json:"message"`
+}
+
+// ConfirmLaunchTemplateUpdate returns the token to pass to UpdateLaunchTemplateAMI.
+// It is valid once, for that template and AMI only.
+func (a *App) ConfirmLaunchTemplateUpdate(templateID string, amiID string) (string, error) {
+	return a.issueConfirmation(actionUpdateLaunchTemplate, templateID+"="+amiID)
+}
+
+// UpdateLaunchTemplateAMI creates a new version of a launch template from its latest
+// version, with amiID as the image. With setDefault the new version becomes the
+// default one, which is what Auto Scaling groups usually launch.
+// With dryRun only the permissions are checked and no token is needed.
+// region is the Region of the audited template; "" is the profile's region.
+func (a *App) UpdateLaunchTemplateAMI(profile string, region string, templateID string, amiID string, token string, dryRun bool, setDefault bool) (*LaunchTemplateUpdateResult, error) {
+	if !dryRun {
+		if err := a.checkWriteAccess(token, actionUpdateLaunchTemplate, templateID+"="+amiID); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if region != "" {
+		cfg.Region = region
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+	result := &LaunchTemplateUpdateResult{TemplateID: templateID, AMI: amiID, DryRun: dryRun}
+
+	// Refuse to point a template at an AMI that doesn't exist in the region
+	img, err := describeAMI(a.ctx, ec2Client, amiID)
+	if err != nil {
+		return nil, err
+	}
+	if img == nil {
+		return nil, fmt.Errorf("%s: %w", amiID, ErrAMIDeregistered)
+	}
+
+	out, err := ec2Client.CreateLaunchTemplateVersion(a.ctx, &ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId:   aws.String(templateID),
+		SourceVersion:      aws.String("$Latest"),
+		VersionDescription: aws.String("AMI updated to " + amiID),
+		LaunchTemplateData: &ec2types.RequestLaunchTemplateData{ImageId: aws.String(amiID)},
+		DryRun:             aws.Bool(dryRun),
+	})
+	if dryRun && isDryRunSuccess(err) {
+		result.Message = fmt.Sprintf("dry run: a new version of %s with %s would be created", templateID, amiID)
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create launch template version of %s: %w", templateID, err)
+	}
+	result.Version = aws.ToInt64(out.LaunchTemplateVersion.VersionNumber)
+	result.Message = fmt.Sprintf("created version %d of %s with %s", result.Version, templateID, amiID)
+
+	if setDefault {
+		_, err := ec2Client.ModifyLaunchTemplate(a.ctx, &ec2.ModifyLaunchTemplateInput{
+			LaunchTemplateId: aws.String(templateID),
+			DefaultVersion:   aws.String(fmt.Sprint(result.Version)),
+		})
+		if err != nil {
+			return result, fmt.Errorf("created version %d but failed to make it the default: %w", result.Version, err)
+		}
+		result.SetDefault = true
+		result.Message += ", now the default version"
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sort"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// LifecycleOnDemand is the lifecycle of instances EC2 reports without one
+const LifecycleOnDemand = "on-demand"
+
+// LifecycleCount counts the instances of one lifecycle and how many run a stale AMI
+type LifecycleCount struct {
+	Lifecycle string `json:"lifecycle"`
+	Instances int    `json:"instances"`
+	Stale     int    `json:"stale"`
+}
+
+// instanceLifecycle returns spot, scheduled, capacity-block or on-demand
+func instanceLifecycle(inst ec2types.Instance) string {
+	if inst.InstanceLifecycle == "" {
+		return LifecycleOnDemand
+	}
+	return string(inst.InstanceLifecycle)
+}
+
+// lifecycleBreakdown counts the instances per lifecycle, on-demand first
+func lifecycleBreakdown(instances []EC2Instance) []LifecycleCount {
+	index := make(map[string]int)
+	counts := []LifecycleCount{}
+	for _, inst := range instances {
+		i, ok := index[inst.Lifecycle]
+		if !ok {
+			i = len(counts)
+			index[inst.Lifecycle] = i
+			counts = append(counts, LifecycleCount{Lifecycle: inst.Lifecycle})
+		}
+		counts[i].Instances++
+		if inst.AMIStale {
+			counts[i].Stale++
+		}
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if (counts[i].Lifecycle == LifecycleOnDemand) != (counts[j].Lifecycle == LifecycleOnDemand) {
+			return counts[i].Lifecycle == LifecycleOnDemand
+		}
+		return counts[i].Lifecycle < counts[j].Lifecycle
+	})
+	return counts
+}
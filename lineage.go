@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// maxLineageDepth stops the walk on very long or looping chains
+const maxLineageDepth = 20
+
+// lineageTagKeys are the tag keys image pipelines (Packer, EC2 Image Builder, custom
+// scripts) commonly use to record the parent AMI, compared case-insensitively
+var lineageTagKeys = []string{"SourceAMI", "source_ami", "SourceAmiId", "source_ami_id", "BaseAMI", "base_ami", "base_ami_id", "ParentAMI", "parent_ami"}
+
+var amiIDPattern = regexp.MustCompile(`ami-[0-9a-f]{8,17}`)
+
+// LineageStep is one image of a lineage chain
+type LineageStep struct {
+	Image  AMIImage `json:"image"`
+	Region string   `json:"region"`
+	// Via tells how this image was found from the previous one:
+	// "sourceImageId", "tag:<key>" or "description"; empty for the starting AMI
+	Via string `json:"via"`
+	// Missing is set when the parent is referenced but can no longer be described
+	Missing bool `json:"missing"`
+}
+
+// AMILineage is the chain from an AMI back to its base image, child first
+type AMILineage struct {
+	Steps []LineageStep `json:"steps"`
+	// Complete is false when the walk stopped on a missing image, a loop or the depth limit
+	Complete bool `json:"complete"`
+}
+
+// TraceAMILineage follows SourceImageId, then the usual parent tags, then an AMI ID
+// in the description, to walk from amiID back to the image it was derived from.
+func (a *App) TraceAMILineage(profile string, amiID string) (*AMILineage, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	lineage := &AMILineage{}
+	seen := make(map[string]bool)
+	id, region, via := amiID, cfg.Region, ""
+	for depth := 0; depth < maxLineageDepth; depth++ {
+		if seen[region+"/"+id] {
+			return lineage, nil
+		}
+		seen[region+"/"+id] = true
+
+		regionCfg := cfg.Copy()
+		regionCfg.Region = region
+		img, err := describeAMI(a.ctx, ec2.NewFromConfig(regionCfg), id)
+		if err != nil {
+			return nil, err
+		}
+		if img == nil {
+			if depth == 0 {
+				return nil, fmt.Errorf("%s: %w", id, ErrAMIDeregistered)
+			}
+			lineage.Steps = append(lineage.Steps, LineageStep{Image: AMIImage{ID: id}, Region: region, Via: via, Missing: true})
+			return lineage, nil
+		}
+		lineage.Steps = append(lineage.Steps, LineageStep{Image: toAMIImage(*img), Region: region, Via: via})
+
+		parent, parentRegion, parentVia := imageParent(*img)
+		if parent == "" {
+			lineage.Complete = true
+			return lineage, nil
+		}
+		id, via = parent, parentVia
+		if parentRegion != "" {
+			region = parentRegion
+		}
+	}
+	return lineage, nil
+}
+
+// describeAMI returns an image, or nil if it does not exist (anymore)
+func describeAMI(ctx context.Context, client *ec2.Client, id string) (*ec2types.Image, error) {
+	images, err := describeAMIs(ctx, client, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	img, ok := images[id]
+	if !ok {
+		return nil, nil
+	}
+	return &img, nil
+}
+
+// imageParent returns the parent AMI recorded on an image, its region if it differs, and how it was found
+func imageParent(img ec2types.Image) (id, region, via string) {
+	self := aws.ToString(img.ImageId)
+
+	if img.SourceImageId != nil && *img.SourceImageId != self {
+		return *img.SourceImageId, aws.ToString(img.SourceImageRegion), "sourceImageId"
+	}
+
+	for _, key := range lineageTagKeys {
+		for _, tag := range img.Tags {
+			if !strings.EqualFold(aws.ToString(tag.Key), key) {
+				continue
+			}
+			if m := amiIDPattern.FindString(aws.ToString(tag.Value)); m != "" && m != self {
+				return m, "", "tag:" + aws.ToString(tag.Key)
+			}
+		}
+	}
+
+	// e.g. "[Copied ami-0abc from us-east-1] ..." or "Built from ami-0abc by Packer"
+	for _, m := range amiIDPattern.FindAllString(aws.ToString(img.Description), -1) {
+		if m != self {
+			return m, "", "description"
+		}
+	}
+	return "", "", ""
+}
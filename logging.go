@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// maxLogFileBytes is the size above which the log file is rotated at startup
+const maxLogFileBytes = 5 << 20
+
+// logLevel is the level of the default logger, changed by SetLogLevel
+var logLevel = new(slog.LevelVar)
+
+// logFile is the path of the log file, "" when it could not be opened
+var logFile string
+
+// logLevels are the levels SetLogLevel accepts
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// logDir returns where the logs go, following the conventions of each OS:
+// %LocalAppData%\goCheckAmi\logs, ~/Library/Logs/goCheckAmi, or
+// $XDG_STATE_HOME/goCheckAmi (~/.local/state/goCheckAmi)
+func logDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return filepath.Join(dir, "goCheckAmi", "logs"), nil
+		}
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home dir: %w", err)
+		}
+		return filepath.Join(home, "Library", "Logs", "goCheckAmi"), nil
+	default:
+		if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+			return filepath.Join(dir, "goCheckAmi"), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home dir: %w", err)
+		}
+		return filepath.Join(home, ".local", "state", "goCheckAmi"), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user cache dir: %w", err)
+	}
+	return filepath.Join(dir, "goCheckAmi", "logs"), nil
+}
+
+// setupLogging sends the logs, slog and log alike, to stderr and to the log file.
+// Without a log file the logs only go to stderr.
+func setupLogging() {
+	var w io.Writer = os.Stderr
+	if f, err := openLogFile(); err == nil {
+		w = io.MultiWriter(os.Stderr, f)
+		logFile = f.Name()
+	} else {
+		defer slog.Warn("Unable to open the log file", "err", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: logLevel})))
+}
+
+// openLogFile opens goCheckAmi.log for appending, first moving it to goCheckAmi.log.1
+// when it grew over maxLogFileBytes
+func openLogFile() (*os.File, error) {
+	dir, err := logDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log dir: %w", err)
+	}
+	path := filepath.Join(dir, "goCheckAmi.log")
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogFileBytes {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return nil, fmt.Errorf("failed to rotate %s: %w", path, err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// setLogLevel parses and applies a level; "" keeps info
+func setLogLevel(level string) error {
+	l, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	logLevel.Set(l)
+	return nil
+}
+
+// parseLogLevel parses debug, info, warn or error; "" is info
+func parseLogLevel(level string) (slog.Level, error) {
+	if level == "" {
+		level = "info"
+	}
+	l, ok := logLevels[strings.ToLower(level)]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q: must be debug, info, warn or error", level)
+	}
+	return l, nil
+}
+
+// SetLogLevel changes the log level until the app exits: debug, info, warn or error.
+// The log level of the settings is applied on startup.
+func (a *App) SetLogLevel(level string) error {
+	return setLogLevel(level)
+}
+
+// GetLogLevel returns the current log level
+func (a *App) GetLogLevel() string {
+	return strings.ToLower(logLevel.Level().String())
+}
+
+// LogFilePath returns the log file to attach to bug reports, "" when logs only go to stderr
+func (a *App) LogFilePath() string {
+	return logFile
+}
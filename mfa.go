@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrMFARequired is returned when a profile needs an MFA code but no token provider is registered
+var ErrMFARequired = errors.New("mfa token required but no MFA token provider is registered")
+
+// mfaPromptTimeout bounds how long we wait for the user to type the code
+const mfaPromptTimeout = 2 * time.Minute
+
+var mfaCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// MFAPrompt is the payload of the mfa:prompt event, telling the user which device to use
+type MFAPrompt struct {
+	Profile      string `json:"profile"`
+	SerialNumber string `json:"serialNumber"`
+}
+
+// SetMFATokenProvider registers the callback used to obtain a TOTP code for profiles with mfa_serial
+func (a *App) SetMFATokenProvider(provider func() (string, error)) {
+	if provider == nil {
+		a.SetMFAPromptProvider(nil)
+		return
+	}
+	a.SetMFAPromptProvider(func(MFAPrompt) (string, error) { return provider() })
+}
+
+// SetMFAPromptProvider is SetMFATokenProvider with a callback told which profile
+// and device the code is for
+func (a *App) SetMFAPromptProvider(provider func(prompt MFAPrompt) (string, error)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mfaTokenProvider = provider
+}
+
+func (a *App) getMFATokenProvider() func(MFAPrompt) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.mfaTokenProvider
+}
+
+// mfaSerial returns the MFA device of the profile, or of the first role of its
+// source_profile chain configured with one; "" when no MFA is needed
+func (a *App) mfaSerial(profile string) string {
+	if serial := a.getProfileValue(profile, "mfa_serial"); serial != "" {
+		return serial
+	}
+	hops, _ := a.roleChain(profile)
+	for _, h := range hops {
+		if h.mfaSerial != "" {
+			return h.mfaSerial
+		}
+	}
+	return ""
+}
+
+// profileRequiresMFA reports whether the profile, or any role of its
+// source_profile chain, is configured with an mfa_serial
+func (a *App) profileRequiresMFA(profile string) bool {
+	return a.mfaSerial(profile) != ""
+}
+
+// SubmitMFAToken delivers the code typed in the frontend prompt to the pending MFA request
+func (a *App) SubmitMFAToken(code string) error {
+	code = strings.TrimSpace(code)
+	if !mfaCodePattern.MatchString(code) {
+		return fmt.Errorf("invalid MFA code: expected 6 digits")
+	}
+
+	select {
+	case a.mfaCodes <- code:
+		return nil
+	default:
+		return fmt.Errorf("no MFA prompt is pending")
+	}
+}
+
+// promptMFAToken asks the frontend for a code and waits until it is submitted.
+// Prompts are serialized so concurrent scans (multi-profile) can't swap codes.
+func (a *App) promptMFAToken(prompt MFAPrompt) (string, error) {
+	a.mfaPromptMu.Lock()
+	defer a.mfaPromptMu.Unlock()
+
+	a.emit("mfa:prompt", prompt)
+
+	select {
+	case code := <-a.mfaCodes:
+		return code, nil
+	case <-time.After(mfaPromptTimeout):
+		a.emit("mfa:cancel", prompt)
+		return "", fmt.Errorf("timed out waiting for MFA code for profile %q", prompt.Profile)
+	case <-a.ctx.Done():
+		return "", a.ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxProfileWorkers bounds how many profiles are scanned at the same time
+const maxProfileWorkers = 4
+
+// ProfileResult is the scan of a single profile in a multi-profile run
+type ProfileResult struct {
+	Profile   string     `json:"profile"`
+	AccountID string     `json:"accountId"`
+	Result    *AWSResult `json:"result"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// MultiProfileResult groups the results of ProcessingMulti by profile
+type MultiProfileResult struct {
+	Profiles []ProfileResult `json:"profiles"`
+}
+
+// ProcessingMulti scans several profiles concurrently with the same filter.
+// Each profile is scanned in its default region; failures are reported per profile.
+func (a *App) ProcessingMulti(profiles []string, filter string) (*MultiProfileResult, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no profiles to scan")
+	}
+
+	out := &MultiProfileResult{Profiles: make([]ProfileResult, len(profiles))}
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxProfileWorkers)
+	for i, profile := range profiles {
+		g.Go(func() error {
+			// Each goroutine only writes its own slot
+			out.Profiles[i] = a.scanProfile(a.ctx, ProcessingRequest{Profile: profile, Filter: SSMFilter{NamePrefix: filter}})
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return out, nil
+}
+
+// scanProfile authenticates and scans one profile, recording the account it belongs to
+func (a *App) scanProfile(ctx context.Context, req ProcessingRequest) ProfileResult {
+	res := ProfileResult{Profile: req.Profile}
+
+	cfg, identity, err := a.authenticateIdentity(ctx, req.Profile)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.AccountID = aws.ToString(identity.Account)
+
+	res.Result, err = a.scanRegion(ctx, cfg, req)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Result.Identity = a.callerIdentity(ctx, cfg, identity)
+	return res
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// notifyTimeout bounds the delivery of a notification to one notifier
+const notifyTimeout = 15 * time.Second
+
+// ScanNotification summarizes a scheduled scan for the notifiers
+type ScanNotification struct {
+	Preset    string `json:"preset"`
+	Profile   string `json:"profile"`
+	ScannedAt string `json:"scannedAt"`
+	Instances int    `json:"instances"`
+	// StaleAMIs and DeprecatedAMIs count the distinct AMIs of the instances
+	StaleAMIs      int `json:"staleAmis"`
+	DeprecatedAMIs int `json:"deprecatedAmis"`
+	// ParameterChanges counts the parameters added, removed or updated since the
+	// previous scan of the preset, 0 on its first scan
+	ParameterChanges int `json:"parameterChanges"`
+	// NewStaleAMIs are the AMIs that became stale since the previous scan
+	NewStaleAMIs []string `json:"newStaleAmis"`
+	// Result is the scan, for the notifiers sending a report; nil for a test notification
+	Result *AWSResult `json:"-"`
+}
+
+// notifier delivers scan notifications to an external service
+type notifier interface {
+	name() string
+	notify(ctx context.Context, n ScanNotification) error
+}
+
+// newScanNotification summarizes result, compared with previous when there is one
+func newScanNotification(preset, profile string, previous, result *AWSResult) ScanNotification {
+	n := ScanNotification{
+		Preset:    preset,
+		Profile:   profile,
+		ScannedAt: result.ScannedAt,
+		Instances: len(result.Instances),
+		Result:    result,
+	}
+	stale, deprecated := make(map[string]bool), make(map[string]bool)
+	for _, inst := range result.Instances {
+		if inst.AMI == "" {
+			continue
+		}
+		if inst.AMIStale {
+			stale[inst.AMI] = true
+		}
+		if inst.AMIStatus == AMIStatusDeprecated {
+			deprecated[inst.AMI] = true
+		}
+	}
+	n.StaleAMIs, n.DeprecatedAMIs = len(stale), len(deprecated)
+	if previous != nil {
+		n.ParameterChanges = len(parameterChanges(previous, result))
+		n.NewStaleAMIs = newStaleAMIs(previous, result)
+	}
+	return n
+}
+
+// setNotifiers replaces the notifiers from the settings
+func (a *App) setNotifiers(prefs UserPrefs) error {
+	notifiers, err := a.newNotifiers(prefs)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.notifiers = notifiers
+	a.mu.Unlock()
+	return nil
+}
+
+// newNotifiers builds the notifiers configured in the settings
+func (a *App) newNotifiers(prefs UserPrefs) ([]notifier, error) {
+	var notifiers []notifier
+	if prefs.SlackWebhookURL != "" {
+		slack, err := newSlackNotifier(prefs.SlackWebhookURL)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, slack)
+	}
+	if len(prefs.EmailTo) > 0 {
+		ses, err := newSESNotifier(a, prefs.EmailProfile, prefs.EmailFrom, prefs.EmailTo)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, ses)
+	}
+	if prefs.SNSTopicARN != "" {
+		sns, err := newSNSNotifier(a, prefs.SNSProfile, prefs.SNSTopicARN, prefs.SNSViolationsOnly)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, sns)
+	}
+	return notifiers, nil
+}
+
+// notify sends the notification to every notifier; failures are logged
+func (a *App) notify(n ScanNotification) {
+	a.mu.Lock()
+	notifiers := a.notifiers
+	a.mu.Unlock()
+
+	for _, nt := range notifiers {
+		ctx, cancel := context.WithTimeout(a.ctx, notifyTimeout)
+		if err := nt.notify(ctx, n); err != nil {
+			slog.Warn("Unable to notify", "notifier", nt.name(), "err", err)
+		}
+		cancel()
+	}
+}
+
+// TestNotifications sends a sample notification to every configured notifier
+func (a *App) TestNotifications() error {
+	a.mu.Lock()
+	notifiers := a.notifiers
+	a.mu.Unlock()
+	if len(notifiers) == 0 {
+		return fmt.Errorf("no notifier is configured")
+	}
+
+	n := ScanNotification{Preset: "test", Profile: "test", ScannedAt: time.Now().UTC().Format(time.RFC3339)}
+	var errs []error
+	for _, nt := range notifiers {
+		ctx, cancel := context.WithTimeout(a.ctx, notifyTimeout)
+		if err := nt.notify(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", nt.name(), err))
+		}
+		cancel()
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultAuditRole is the role Organizations creates in the accounts it provisions
+const defaultAuditRole = "OrganizationAccountAccessRole"
+
+// auditSessionName identifies the scan in the CloudTrail of the member accounts
+const auditSessionName = "goCheckAmi"
+
+// maxAccountWorkers bounds how many accounts are scanned at the same time
+const maxAccountWorkers = 4
+
+// OrgScanRequest describes an organization-wide scan from a management account profile
+type OrgScanRequest struct {
+	// Scan is applied to every account; its Profile is the management account
+	Scan ProcessingRequest `json:"scan"`
+	// AuditRole is the role name assumed in each member account (default OrganizationAccountAccessRole)
+	AuditRole string `json:"auditRole"`
+	// ExternalID is passed when assuming the audit role, if the role requires one
+	ExternalID string `json:"externalId"`
+	// Accounts restricts the scan to these account IDs; empty scans every active account
+	Accounts []string `json:"accounts"`
+}
+
+// AccountResult is the scan of one member account
+type AccountResult struct {
+	AccountID   string     `json:"accountId"`
+	AccountName string     `json:"accountName"`
+	RoleARN     string     `json:"roleArn,omitempty"`
+	Result      *AWSResult `json:"result"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// OrgScanResult groups the results of ProcessingOrg by account
+type OrgScanResult struct {
+	ManagementAccountID string          `json:"managementAccountId"`
+	Accounts            []AccountResult `json:"accounts"`
+}
+
+// orgAccount is an active account of the organization
+type orgAccount struct {
+	ID   string
+	Name string
+}
+
+// ProcessingOrg lists the accounts of the organization, assumes the audit role
+// in each and runs the scan org-wide. It can be cancelled through Scan.RequestID;
+// failures are reported per account.
+func (a *App) ProcessingOrg(req OrgScanRequest) (*OrgScanResult, error) {
+	ctx, done, err := a.beginRequest(req.Scan.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ctx = withRequestID(ctx, req.Scan.RequestID)
+
+	result, err := a.processOrg(ctx, req)
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return nil, fmt.Errorf("request %q cancelled", req.Scan.RequestID)
+	}
+	return result, err
+}
+
+func (a *App) processOrg(ctx context.Context, req OrgScanRequest) (*OrgScanResult, error) {
+	if req.Scan.Refresh {
+		a.InvalidateProfile(req.Scan.Profile)
+	}
+	cfg, identity, err := a.authenticateIdentity(ctx, req.Scan.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := listOrgAccounts(ctx, organizations.NewFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Accounts) > 0 {
+		wanted := make(map[string]bool, len(req.Accounts))
+		for _, id := range req.Accounts {
+			wanted[id] = true
+		}
+		var selected []orgAccount
+		for _, acct := range accounts {
+			if wanted[acct.ID] {
+				selected = append(selected, acct)
+			}
+		}
+		accounts = selected
+	}
+
+	role := req.AuditRole
+	if role == "" {
+		role = defaultAuditRole
+	}
+	managementID := aws.ToString(identity.Account)
+	partition := arnPartition(aws.ToString(identity.Arn))
+
+	out := &OrgScanResult{ManagementAccountID: managementID, Accounts: make([]AccountResult, len(accounts))}
+	g := new(errgroup.Group)
+	g.SetLimit(maxAccountWorkers)
+	for i, acct := range accounts {
+		g.Go(func() error {
+			// Each goroutine only writes its own slot
+			res := AccountResult{AccountID: acct.ID, AccountName: acct.Name}
+			acctCfg := cfg
+			// The management account is scanned with the profile's own credentials
+			if acct.ID != managementID {
+				res.RoleARN = fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, acct.ID, role)
+				acctCfg = assumeRoleConfig(cfg, res.RoleARN, req.ExternalID)
+			}
+			var scanErr error
+			res.Result, scanErr = a.scanAccount(ctx, acctCfg, req.Scan)
+			if scanErr != nil {
+				res.Error = scanErr.Error()
+			}
+			out.Accounts[i] = res
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return out, ctx.Err()
+}
+
+// listOrgAccounts returns the active accounts of the organization. The SDK resolves
+// the global endpoint of the partition, us-east-1 for aws, whatever the client region.
+func listOrgAccounts(ctx context.Context, client *organizations.Client) ([]orgAccount, error) {
+	var accounts []orgAccount
+	pager := organizations.NewListAccountsPaginator(client, &organizations.ListAccountsInput{})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts: %w", err)
+		}
+		for _, acct := range page.Accounts {
+			if acct.Status == orgtypes.AccountStatusActive {
+				accounts = append(accounts, orgAccount{ID: aws.ToString(acct.Id), Name: aws.ToString(acct.Name)})
+			}
+		}
+	}
+	return accounts, nil
+}
+
+// assumeRoleConfig returns a copy of cfg using the credentials of roleARN,
+// assumed with the credentials of cfg
+func assumeRoleConfig(cfg aws.Config, roleARN, externalID string) aws.Config {
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = auditSessionName
+		if externalID != "" {
+			o.ExternalID = aws.String(externalID)
+		}
+	})
+	assumed := cfg.Copy()
+	assumed.Credentials = aws.NewCredentialsCache(provider)
+	return assumed
+}
+
+// arnPartition returns the partition of an ARN (aws, aws-cn, aws-us-gov), "aws" if it can't be parsed
+func arnPartition(arn string) string {
+	parts := strings.SplitN(arn, ":", 3)
+	if len(parts) < 3 || parts[1] == "" {
+		return "aws"
+	}
+	return parts[1]
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// OrphanedAMI is an AMI owned by the account that no instance or launch template uses
+type OrphanedAMI struct {
+	Image           AMIImage `json:"image"`
+	SnapshotIDs     []string `json:"snapshotIds"`
+	SnapshotSizeGiB int32    `json:"snapshotSizeGiB"`
+}
+
+// OrphanReport lists the unused AMIs of a region
+type OrphanReport struct {
+	Region               string        `json:"region"`
+	OwnedCount           int           `json:"ownedCount"`
+	Orphaned             []OrphanedAMI `json:"orphaned"`
+	TotalSnapshotSizeGiB int32         `json:"totalSnapshotSizeGiB"`
+}
+
+// FindOrphanedAMIs lists the AMIs owned by the account in the profile's region
+// that are used neither by an instance (in any state but terminated) nor by the
+// latest or default version of a launch template, nor by the launch template
+// version an Auto Scaling group launches.
+func (a *App) FindOrphanedAMIs(profile string) (*OrphanReport, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	owned, err := ownedAMIs(a.ctx, ec2Client)
+	if err != nil {
+		return nil, err
+	}
+	inUse, err := amisInUse(a.ctx, ec2Client, autoscaling.NewFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanReport{Region: cfg.Region, OwnedCount: len(owned), Orphaned: []OrphanedAMI{}}
+	for _, img := range owned {
+		if inUse[aws.ToString(img.ImageId)] {
+			continue
+		}
+		orphan := OrphanedAMI{Image: toAMIImage(img)}
+		orphan.SnapshotIDs, orphan.SnapshotSizeGiB = imageSnapshots(img)
+		report.TotalSnapshotSizeGiB += orphan.SnapshotSizeGiB
+		report.Orphaned = append(report.Orphaned, orphan)
+	}
+
+	// Oldest first, they are the first candidates for cleanup
+	sort.Slice(report.Orphaned, func(i, j int) bool {
+		return report.Orphaned[i].Image.CreationDate < report.Orphaned[j].Image.CreationDate
+	})
+	return report, nil
+}
+
+// ownedAMIs returns the images owned by the calling account
+func ownedAMIs(ctx context.Context, client ec2.DescribeImagesAPIClient) ([]ec2types.Image, error) {
+	var images []ec2types.Image
+	pager := ec2.NewDescribeImagesPaginator(client, &ec2.DescribeImagesInput{
+		Owners:            []string{"self"},
+		IncludeDeprecated: aws.Bool(true),
+		IncludeDisabled:   aws.Bool(true),
+	})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe owned images: %w", err)
+		}
+		images = append(images, page.Images...)
+	}
+	return images, nil
+}
+
+// amisInUse returns the AMIs referenced by instances, launch templates and Auto
+// Scaling groups of the region
+func amisInUse(ctx context.Context, client *ec2.Client, asgClient *autoscaling.Client) (map[string]bool, error) {
+	inUse := make(map[string]bool)
+
+	instPager := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped", "shutting-down"}},
+		},
+	})
+	for instPager.HasMorePages() {
+		page, err := instPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances: %w", err)
+		}
+		for _, res := range page.Reservations {
+			for _, inst := range res.Instances {
+				if inst.ImageId != nil {
+					inUse[*inst.ImageId] = true
+				}
+			}
+		}
+	}
+
+	// Without a template ID, $Latest and $Default return those versions of every template
+	if err := templateVersionAMIs(ctx, client, &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions: []string{"$Latest", "$Default"},
+	}, inUse); err != nil {
+		return nil, err
+	}
+
+	// Groups may pin an older numbered version, which scale-out still launches
+	groups, err := autoScalingGroups(ctx, asgClient)
+	if err != nil {
+		return nil, err
+	}
+	// Templates are referenced by ID or by name
+	type templateRef struct{ id, name string }
+	pinned := make(map[templateRef][]string)
+	for _, g := range groups {
+		specs := []*autoscalingtypes.LaunchTemplateSpecification{asgLaunchTemplate(g)}
+		if p := g.MixedInstancesPolicy; p != nil && p.LaunchTemplate != nil {
+			for _, o := range p.LaunchTemplate.Overrides {
+				specs = append(specs, o.LaunchTemplateSpecification)
+			}
+		}
+		for _, spec := range specs {
+			if spec == nil {
+				continue
+			}
+			version := launchTemplateVersionOf(spec)
+			if version == "$Latest" || version == "$Default" {
+				continue
+			}
+			template := templateRef{id: aws.ToString(spec.LaunchTemplateId), name: aws.ToString(spec.LaunchTemplateName)}
+			if !slices.Contains(pinned[template], version) {
+				pinned[template] = append(pinned[template], version)
+			}
+		}
+	}
+	for template, versions := range pinned {
+		in := &ec2.DescribeLaunchTemplateVersionsInput{Versions: versions}
+		if template.id != "" {
+			in.LaunchTemplateId = aws.String(template.id)
+		} else {
+			in.LaunchTemplateName = aws.String(template.name)
+		}
+		if err := templateVersionAMIs(ctx, client, in, inUse); err != nil {
+			return nil, err
+		}
+	}
+	return inUse, nil
+}
+
+// templateVersionAMIs adds the AMIs of the launch template versions matching in to inUse
+func templateVersionAMIs(ctx context.Context, client *ec2.Client, in *ec2.DescribeLaunchTemplateVersionsInput, inUse map[string]bool) error {
+	pager := ec2.NewDescribeLaunchTemplateVersionsPaginator(client, in)
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe launch template versions: %w", err)
+		}
+		for _, v := range page.LaunchTemplateVersions {
+			if v.LaunchTemplateData == nil || v.LaunchTemplateData.ImageId == nil {
+				continue
+			}
+			// "resolve:ssm:..." references cannot be matched to an owned AMI
+			if id := *v.LaunchTemplateData.ImageId; strings.HasPrefix(id, "ami-") {
+				inUse[id] = true
+			}
+		}
+	}
+	return nil
+}
+
+// imageSnapshots returns the EBS snapshots backing an image and their total size
+func imageSnapshots(img ec2types.Image) ([]string, int32) {
+	ids := []string{}
+	var size int32
+	for _, bdm := range img.BlockDeviceMappings {
+		if bdm.Ebs == nil || bdm.Ebs.SnapshotId == nil {
+			continue
+		}
+		ids = append(ids, *bdm.Ebs.SnapshotId)
+		size += aws.ToInt32(bdm.Ebs.VolumeSize)
+	}
+	return ids, size
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// ParameterTransform rewrites the name prefix of copied parameters, e.g. /app/dev/ -> /app/staging/.
+// Names not starting with From are copied unchanged.
+type ParameterTransform struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// apply returns the destination name of a parameter
+func (t ParameterTransform) apply(name string) string {
+	if t.From == "" || !strings.HasPrefix(name, t.From) {
+		return name
+	}
+	return t.To + strings.TrimPrefix(name, t.From)
+}
+
+// ParameterCopyResult is the plan of a copy (the dry-run diff) and, unless it was a dry run,
+// what was written. Key is the destination name.
+type ParameterCopyResult struct {
+	DryRun    bool              `json:"dryRun"`
+	Create    []ParameterEntry  `json:"create"`
+	Update    []ParameterChange `json:"update"`
+	Unchanged int               `json:"unchanged"`
+	Written   []string          `json:"written"`
+	Failed    map[string]string `json:"failed"`
+}
+
+// CopyParameters copies the parameters under path from srcProfile to dstProfile, renaming them
+// with transform. Run it with dryRun first to see what would be created or updated; the token
+// comes from ConfirmParameterWrite("<dstProfile>:<path>", "copy-parameters").
+// SecureStrings are encrypted with the destination's default key, since source keys
+// usually don't exist in the other account.
+func (a *App) CopyParameters(srcProfile, dstProfile, path string, transform ParameterTransform, token string, dryRun bool) (*ParameterCopyResult, error) {
+	path = diffPath(path)
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q: must start with /", path)
+	}
+	if !dryRun {
+		if err := a.checkWriteAccess(token, ParameterActionCopy, dstProfile+":"+path); err != nil {
+			return nil, err
+		}
+	}
+
+	srcCfg, err := a.authenticate(a.ctx, srcProfile)
+	if err != nil {
+		return nil, err
+	}
+	dstCfg, err := a.authenticate(a.ctx, dstProfile)
+	if err != nil {
+		return nil, err
+	}
+	dstClient := ssm.NewFromConfig(dstCfg)
+
+	src, err := parametersUnderPath(a.ctx, ssm.NewFromConfig(srcCfg), path)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]ssmtypes.Parameter, len(src))
+	for _, p := range src {
+		sources[transform.apply(aws.ToString(p.Name))] = p
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dst, err := getParameters(a.ctx, dstClient, names, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ParameterCopyResult{
+		DryRun:  dryRun,
+		Create:  []ParameterEntry{},
+		Update:  []ParameterChange{},
+		Written: []string{},
+		Failed:  make(map[string]string),
+	}
+	var toWrite []string
+	for _, name := range names {
+		sp := sources[name]
+		dp, exists := dst[name]
+		switch {
+		case !exists:
+			result.Create = append(result.Create, parameterEntry(name, sp))
+		case aws.ToString(sp.Value) != aws.ToString(dp.Value) || sp.Type != dp.Type:
+			result.Update = append(result.Update, ParameterChange{Key: name, A: parameterEntry(name, sp), B: parameterEntry(name, dp)})
+		default:
+			result.Unchanged++
+			continue
+		}
+		toWrite = append(toWrite, name)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	// A failing parameter doesn't stop the copy; it is reported in Failed
+	for _, name := range toWrite {
+		sp := sources[name]
+		_, err := dstClient.PutParameter(a.ctx, &ssm.PutParameterInput{
+			Name:      aws.String(name),
+			Value:     sp.Value,
+			Type:      sp.Type,
+			Overwrite: aws.Bool(true),
+		})
+		if err != nil {
+			result.Failed[name] = err.Error()
+			continue
+		}
+		result.Written = append(result.Written, name)
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// ParameterEntry is a parameter of one side of a diff.
+// Key is its name relative to the compared path.
+type ParameterEntry struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Value is masked for SecureString parameters
+	Value string `json:"value"`
+}
+
+// ParameterChange is a key present on both sides with a different value or type
+type ParameterChange struct {
+	Key string         `json:"key"`
+	A   ParameterEntry `json:"a"`
+	B   ParameterEntry `json:"b"`
+}
+
+// ParameterDiff compares the parameters under pathA (profile A) with those under pathB (profile B).
+// Added keys only exist in B, removed keys only in A.
+type ParameterDiff struct {
+	PathA     string            `json:"pathA"`
+	PathB     string            `json:"pathB"`
+	Added     []ParameterEntry  `json:"added"`
+	Removed   []ParameterEntry  `json:"removed"`
+	Changed   []ParameterChange `json:"changed"`
+	Unchanged int               `json:"unchanged"`
+}
+
+// DiffParameters compares the parameters under two hierarchies, e.g. /app/prod/* and /app/dev/*,
+// possibly in different profiles. profileB defaults to profileA.
+// SecureString values are decrypted to be compared, but never returned.
+func (a *App) DiffParameters(profileA, profileB, pathA, pathB string) (*ParameterDiff, error) {
+	if profileB == "" {
+		profileB = profileA
+	}
+	pathA, pathB = diffPath(pathA), diffPath(pathB)
+	for _, p := range []string{pathA, pathB} {
+		if !strings.HasPrefix(p, "/") {
+			return nil, fmt.Errorf("invalid path %q: must start with /", p)
+		}
+	}
+
+	cfgA, err := a.authenticate(a.ctx, profileA)
+	if err != nil {
+		return nil, err
+	}
+	cfgB := cfgA
+	if profileB != profileA {
+		if cfgB, err = a.authenticate(a.ctx, profileB); err != nil {
+			return nil, err
+		}
+	}
+
+	paramsA, err := parametersUnderPath(a.ctx, ssm.NewFromConfig(cfgA), pathA)
+	if err != nil {
+		return nil, err
+	}
+	paramsB, err := parametersUnderPath(a.ctx, ssm.NewFromConfig(cfgB), pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ParameterDiff{
+		PathA:   pathA,
+		PathB:   pathB,
+		Added:   []ParameterEntry{},
+		Removed: []ParameterEntry{},
+		Changed: []ParameterChange{},
+	}
+	for key, pa := range paramsA {
+		pb, ok := paramsB[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, parameterEntry(key, pa))
+			continue
+		}
+		if aws.ToString(pa.Value) == aws.ToString(pb.Value) && pa.Type == pb.Type {
+			diff.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, ParameterChange{Key: key, A: parameterEntry(key, pa), B: parameterEntry(key, pb)})
+	}
+	for key, pb := range paramsB {
+		if _, ok := paramsA[key]; !ok {
+			diff.Added = append(diff.Added, parameterEntry(key, pb))
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Key < diff.Added[j].Key })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Key < diff.Removed[j].Key })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Key < diff.Changed[j].Key })
+	return diff, nil
+}
+
+// diffPath turns "/app/prod/*" or "/app/prod/" into "/app/prod"
+func diffPath(path string) string {
+	path = strings.TrimRight(strings.TrimSpace(path), "*")
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// parametersUnderPath returns the decrypted parameters below a path, keyed by their relative name
+func parametersUnderPath(ctx context.Context, ssmClient *ssm.Client, path string) (map[string]ssmtypes.Parameter, error) {
+	params := make(map[string]ssmtypes.Parameter)
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	paginator := ssm.NewGetParametersByPathPaginator(ssmClient, &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get params by path %s: %w", path, err)
+		}
+		for _, p := range page.Parameters {
+			name := aws.ToString(p.Name)
+			params[strings.TrimPrefix(name, prefix)] = p
+		}
+	}
+	return params, nil
+}
+
+// parameterEntry converts a parameter, masking SecureString values
+func parameterEntry(key string, p ssmtypes.Parameter) ParameterEntry {
+	e := ParameterEntry{Key: key, Name: aws.ToString(p.Name), Type: string(p.Type), Value: aws.ToString(p.Value)}
+	if p.Type == ssmtypes.ParameterTypeSecureString {
+		e.Value = maskedValue
+	}
+	return e
+}
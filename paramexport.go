@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats
+const (
+	ExportDotenv = "dotenv"
+	ExportJSON   = "json"
+	ExportYAML   = "yaml"
+)
+
+// exportExtensions are the default file extensions of each export format
+var exportExtensions = map[string]string{
+	ExportDotenv: ".env",
+	ExportJSON:   ".json",
+	ExportYAML:   ".yaml",
+}
+
+// ExportParameters writes the parameters matching filter to a file the user picks,
+// as dotenv, json or yaml. SecureString values are masked unless decrypt is set.
+// It returns the path written, or "" if the user cancelled the dialog.
+func (a *App) ExportParameters(profile string, filter SSMFilter, format string, decrypt bool) (string, error) {
+	ext, ok := exportExtensions[format]
+	if !ok {
+		return "", fmt.Errorf("invalid export format %q: must be one of dotenv, json, yaml", format)
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export parameters",
+		DefaultFilename: "parameters" + ext,
+		Filters:         []runtime.FileFilter{{DisplayName: format, Pattern: "*" + ext}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	return path, a.ExportParametersToFile(profile, filter, format, decrypt, path)
+}
+
+// ExportParametersToFile is ExportParameters without the dialog
+func (a *App) ExportParametersToFile(profile string, filter SSMFilter, format string, decrypt bool, path string) error {
+	filters, err := filter.parameterFilters()
+	if err != nil {
+		return err
+	}
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return err
+	}
+	client := ssm.NewFromConfig(cfg)
+
+	params, err := a.listFilteredParameters(a.ctx, client, cfg.Region, filter, filters)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	values, err := parameterValues(a.ctx, client, names, decrypt)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeParameters(values, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// parameterValues fetches the values of the named parameters.
+// SecureString values are masked unless decrypt is set.
+func parameterValues(ctx context.Context, ssmClient *ssm.Client, names []string, decrypt bool) (map[string]string, error) {
+	params, err := getParameters(ctx, ssmClient, names, decrypt)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(params))
+	for name, p := range params {
+		value := aws.ToString(p.Value)
+		if p.Type == ssmtypes.ParameterTypeSecureString && !decrypt {
+			value = maskedValue
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// getParameters fetches the named parameters by batches, keyed by name.
+// Missing parameters come back in InvalidParameters and are left out.
+func getParameters(ctx context.Context, ssmClient *ssm.Client, names []string, decrypt bool) (map[string]ssmtypes.Parameter, error) {
+	params := make(map[string]ssmtypes.Parameter, len(names))
+	for start := 0; start < len(names); start += getParametersBatchSize {
+		end := min(start+getParametersBatchSize, len(names))
+		out, err := ssmClient.GetParameters(ctx, &ssm.GetParametersInput{
+			Names:          names[start:end],
+			WithDecryption: aws.Bool(decrypt),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get params: %w", err)
+		}
+		for _, p := range out.Parameters {
+			params[aws.ToString(p.Name)] = p
+		}
+	}
+	return params, nil
+}
+
+// encodeParameters renders name/value pairs in an export format
+func encodeParameters(values map[string]string, format string) ([]byte, error) {
+	switch format {
+	case ExportJSON:
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode json: %w", err)
+		}
+		return append(data, '\n'), nil
+	case ExportYAML:
+		data, err := yaml.Marshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode yaml: %w", err)
+		}
+		return data, nil
+	case ExportDotenv:
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var b strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s=%s\n", dotenvKey(name), dotenvValue(values[name]))
+		}
+		return []byte(b.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid export format %q: must be one of dotenv, json, yaml", format)
+	}
+}
+
+// dotenvKey turns a parameter name into a variable name: /app/prod/db-host -> APP_PROD_DB_HOST
+func dotenvKey(name string) string {
+	key := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimPrefix(name, "/"))
+	if key != "" && key[0] >= '0' && key[0] <= '9' {
+		key = "_" + key
+	}
+	return key
+}
+
+// dotenvValue double-quotes a value, escaping what dotenv parsers interpret
+func dotenvValue(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+	return `"` + r.Replace(value) + `"`
+}
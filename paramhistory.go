@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// maskedValue replaces the value of SecureString parameters
+const maskedValue = "********"
+
+// ParameterVersion is one version of an SSM parameter
+type ParameterVersion struct {
+	Version int64  `json:"version"`
+	Type    string `json:"type"`
+	// Value is masked for SecureString parameters
+	Value            string   `json:"value"`
+	LastModifiedDate string   `json:"lastModifiedDate"`
+	LastModifiedUser string   `json:"lastModifiedUser"`
+	Labels           []string `json:"labels"`
+	Description      string   `json:"description"`
+}
+
+// GetParameterHistory returns every version of a parameter, newest first
+func (a *App) GetParameterHistory(profile string, name string) ([]ParameterVersion, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []ParameterVersion{}
+	// Not decrypted: SecureString values are masked anyway
+	pager := ssm.NewGetParameterHistoryPaginator(ssm.NewFromConfig(cfg), &ssm.GetParameterHistoryInput{
+		Name: aws.String(name),
+	})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(a.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history of %s: %w", name, err)
+		}
+		for _, p := range page.Parameters {
+			v := ParameterVersion{
+				Version:          p.Version,
+				Type:             string(p.Type),
+				Value:            aws.ToString(p.Value),
+				LastModifiedUser: aws.ToString(p.LastModifiedUser),
+				Labels:           p.Labels,
+				Description:      aws.ToString(p.Description),
+			}
+			if p.Type == ssmtypes.ParameterTypeSecureString {
+				v.Value = maskedValue
+			}
+			if p.LastModifiedDate != nil {
+				v.LastModifiedDate = p.LastModifiedDate.UTC().Format(time.RFC3339)
+			}
+			if v.Labels == nil {
+				v.Labels = []string{}
+			}
+			versions = append(versions, v)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+	return versions, nil
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ParameterImportOptions describes a bulk import from a dotenv or JSON file
+type ParameterImportOptions struct {
+	File string `json:"file"`
+	// Prefix is the path the keys are created under, e.g. /app/staging.
+	// When empty the keys must be full parameter names.
+	Prefix string `json:"prefix"`
+	// Type and KeyID apply to every imported parameter, see ParameterInput
+	Type  string `json:"type"`
+	KeyID string `json:"keyId"`
+	// Overwrite replaces existing parameters instead of skipping them
+	Overwrite bool `json:"overwrite"`
+}
+
+// ParameterImportEntry is a parameter the import would write
+type ParameterImportEntry struct {
+	Name string `json:"name"`
+	// Value is masked when the parameters are imported as SecureString
+	Value string `json:"value"`
+	// Exists is set when the parameter already exists; it is skipped unless Overwrite is set
+	Exists bool `json:"exists"`
+}
+
+// ParameterImportResult reports what ImportParameters wrote
+type ParameterImportResult struct {
+	Created     []string          `json:"created"`
+	Overwritten []string          `json:"overwritten"`
+	Skipped     []string          `json:"skipped"`
+	Failed      map[string]string `json:"failed"`
+}
+
+// ChooseImportFile asks the user for the dotenv or JSON file to import.
+// It returns "" if the user cancelled the dialog.
+func (a *App) ChooseImportFile() (string, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import parameters",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "dotenv or JSON", Pattern: "*.env;*.json"},
+			{DisplayName: "All files", Pattern: "*"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open file dialog: %w", err)
+	}
+	return path, nil
+}
+
+// PreviewParameterImport lists the parameters ImportParameters would write, without writing them
+func (a *App) PreviewParameterImport(profile string, opts ParameterImportOptions) ([]ParameterImportEntry, error) {
+	values, err := readImportFile(opts.File, opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := existingParameters(a.ctx, ssm.NewFromConfig(cfg), values)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ParameterImportEntry, 0, len(values))
+	for name, value := range values {
+		if opts.Type == string(ssmtypes.ParameterTypeSecureString) {
+			value = maskedValue
+		}
+		entries = append(entries, ParameterImportEntry{Name: name, Value: value, Exists: existing[name]})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// ImportParameters writes the parameters of a dotenv or JSON file with PutParameter.
+// The token comes from ConfirmParameterWrite(opts.Prefix, "import-parameters").
+// A failing parameter doesn't stop the import; it is reported in Failed.
+func (a *App) ImportParameters(profile string, opts ParameterImportOptions, token string) (*ParameterImportResult, error) {
+	if opts.Type == "" {
+		opts.Type = string(ssmtypes.ParameterTypeString)
+	}
+	if !validParameterTypes[opts.Type] {
+		return nil, fmt.Errorf("invalid parameter type %q: must be one of String, StringList, SecureString", opts.Type)
+	}
+	if opts.KeyID != "" && opts.Type != string(ssmtypes.ParameterTypeSecureString) {
+		return nil, fmt.Errorf("a KMS key can only be set on SecureString parameters")
+	}
+	values, err := readImportFile(opts.File, opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkWriteAccess(token, ParameterActionImport, opts.Prefix); err != nil {
+		return nil, err
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	client := ssm.NewFromConfig(cfg)
+	existing, err := existingParameters(a.ctx, client, values)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &ParameterImportResult{Created: []string{}, Overwritten: []string{}, Skipped: []string{}, Failed: make(map[string]string)}
+	for _, name := range names {
+		if existing[name] && !opts.Overwrite {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+		in := &ssm.PutParameterInput{
+			Name:      aws.String(name),
+			Value:     aws.String(values[name]),
+			Type:      ssmtypes.ParameterType(opts.Type),
+			Overwrite: aws.Bool(opts.Overwrite),
+		}
+		if opts.KeyID != "" {
+			in.KeyId = aws.String(opts.KeyID)
+		}
+		if _, err := client.PutParameter(a.ctx, in); err != nil {
+			result.Failed[name] = err.Error()
+			continue
+		}
+		if existing[name] {
+			result.Overwritten = append(result.Overwritten, name)
+		} else {
+			result.Created = append(result.Created, name)
+		}
+	}
+	return result, nil
+}
+
+// readImportFile reads a JSON (.json) or dotenv file and returns the values by parameter name
+func readImportFile(file, prefix string) (map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var values map[string]string
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		values, err = parseJSONValues(data)
+	} else {
+		values, err = parseDotenv(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+	named := make(map[string]string, len(values))
+	for key, value := range values {
+		name := key
+		if prefix != "" {
+			name = prefix + "/" + strings.TrimPrefix(key, "/")
+		}
+		named[name] = value
+	}
+	return named, nil
+}
+
+// parseJSONValues reads a flat JSON object; numbers and booleans are kept as text
+func parseJSONValues(data []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(raw))
+	for key, v := range raw {
+		switch v := v.(type) {
+		case string:
+			values[key] = v
+		case float64, bool:
+			values[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("value of %q is not a string, number or boolean", key)
+		}
+	}
+	return values, nil
+}
+
+// parseDotenv reads KEY=VALUE lines, skipping blank lines and comments.
+// Double-quoted values are unescaped as written by dotenvValue; single-quoted values are literal.
+func parseDotenv(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			r := strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\$`, "$")
+			value = r.Replace(value[1 : len(value)-1])
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// existingParameters reports which of the named parameters already exist
+func existingParameters(ctx context.Context, ssmClient *ssm.Client, values map[string]string) (map[string]bool, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	params, err := getParameters(ctx, ssmClient, names, false)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(params))
+	for name := range params {
+		existing[name] = true
+	}
+	return existing, nil
+}
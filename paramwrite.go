@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// Parameter write actions
+const (
+	ParameterActionPut       = "put-parameter"
+	ParameterActionOverwrite = "overwrite-parameter"
+	ParameterActionDelete    = "delete-parameter"
+	// ParameterActionImport targets the import prefix rather than a parameter
+	ParameterActionImport = "import-parameters"
+	// ParameterActionCopy targets "<destination profile>:<source path>"
+	ParameterActionCopy = "copy-parameters"
+)
+
+// ParameterInput is the parameter to create or overwrite
+type ParameterInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Type is String, StringList or SecureString; String when empty
+	Type string `json:"type"`
+	// KeyID is the KMS key of a SecureString, the aws/ssm key when empty
+	KeyID       string `json:"keyId"`
+	Description string `json:"description"`
+}
+
+// ConfirmParameterWrite returns the token to pass to PutParameter, OverwriteParameter,
+// DeleteParameter, ImportParameters or CopyParameters. It is valid once, for that action
+// on that parameter (or import prefix, or copy target) only.
+func (a *App) ConfirmParameterWrite(name string, action string) (string, error) {
+	switch action {
+	case ParameterActionPut, ParameterActionOverwrite, ParameterActionDelete, ParameterActionImport, ParameterActionCopy:
+	default:
+		return "", fmt.Errorf("invalid parameter action %q: must be %s, %s, %s, %s or %s", action,
+			ParameterActionPut, ParameterActionOverwrite, ParameterActionDelete, ParameterActionImport, ParameterActionCopy)
+	}
+	return a.issueConfirmation(action, name)
+}
+
+// PutParameter creates a parameter and returns its version. It fails if the parameter exists.
+func (a *App) PutParameter(profile string, input ParameterInput, token string) (int64, error) {
+	return a.putParameter(profile, input, token, false)
+}
+
+// OverwriteParameter replaces the value of a parameter and returns its new version
+func (a *App) OverwriteParameter(profile string, input ParameterInput, token string) (int64, error) {
+	return a.putParameter(profile, input, token, true)
+}
+
+func (a *App) putParameter(profile string, input ParameterInput, token string, overwrite bool) (int64, error) {
+	action := ParameterActionPut
+	if overwrite {
+		action = ParameterActionOverwrite
+	}
+	if input.Name == "" {
+		return 0, fmt.Errorf("parameter name is required")
+	}
+	if input.Type == "" {
+		input.Type = string(ssmtypes.ParameterTypeString)
+	}
+	if !validParameterTypes[input.Type] {
+		return 0, fmt.Errorf("invalid parameter type %q: must be one of String, StringList, SecureString", input.Type)
+	}
+	if input.KeyID != "" && input.Type != string(ssmtypes.ParameterTypeSecureString) {
+		return 0, fmt.Errorf("a KMS key can only be set on SecureString parameters")
+	}
+	if err := a.checkWriteAccess(token, action, input.Name); err != nil {
+		return 0, err
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return 0, err
+	}
+
+	in := &ssm.PutParameterInput{
+		Name:      aws.String(input.Name),
+		Value:     aws.String(input.Value),
+		Type:      ssmtypes.ParameterType(input.Type),
+		Overwrite: aws.Bool(overwrite),
+	}
+	if input.KeyID != "" {
+		in.KeyId = aws.String(input.KeyID)
+	}
+	if input.Description != "" {
+		in.Description = aws.String(input.Description)
+	}
+	out, err := ssm.NewFromConfig(cfg).PutParameter(a.ctx, in)
+	if err != nil {
+		return 0, fmt.Errorf("failed to put param %s: %w", input.Name, err)
+	}
+	return out.Version, nil
+}
+
+// DeleteParameter deletes a parameter and its history
+func (a *App) DeleteParameter(profile string, name string, token string) error {
+	if err := a.checkWriteAccess(token, ParameterActionDelete, name); err != nil {
+		return err
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return err
+	}
+	if _, err := ssm.NewFromConfig(cfg).DeleteParameter(a.ctx, &ssm.DeleteParameterInput{Name: aws.String(name)}); err != nil {
+		return fmt.Errorf("failed to delete param %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxAttributeWorkers bounds the concurrent DescribeImageAttribute calls
+const maxAttributeWorkers = 8
+
+// AMIPermissionFinding is an owned AMI that can be launched outside the account
+type AMIPermissionFinding struct {
+	Image AMIImage `json:"image"`
+	// Public is set when the AMI is shared with everyone ("all" group)
+	Public bool `json:"public"`
+	// SharedWith lists every account the AMI is shared with
+	SharedWith []string `json:"sharedWith"`
+	// UnknownAccounts are the accounts of SharedWith that are not trusted
+	UnknownAccounts []string `json:"unknownAccounts"`
+	// Organizations lists the organization and OU ARNs the AMI is shared with
+	Organizations []string `json:"organizations"`
+}
+
+// AMIPermissionAudit is the result of AuditAMIPermissions
+type AMIPermissionAudit struct {
+	Region       string                 `json:"region"`
+	OwnedCount   int                    `json:"ownedCount"`
+	PublicCount  int                    `json:"publicCount"`
+	UnknownCount int                    `json:"unknownCount"`
+	Findings     []AMIPermissionFinding `json:"findings"`
+}
+
+// AuditAMIPermissions inspects the launch permissions of the AMIs owned by the account
+// in the profile's region and reports the ones that are public or shared with accounts
+// outside trustedAccounts. Only shared AMIs are returned.
+func (a *App) AuditAMIPermissions(profile string, trustedAccounts []string) (*AMIPermissionAudit, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	owned, err := ownedAMIs(a.ctx, ec2Client)
+	if err != nil {
+		return nil, err
+	}
+
+	trusted := make(map[string]bool, len(trustedAccounts))
+	for _, acct := range trustedAccounts {
+		trusted[acct] = true
+	}
+
+	findings := make([]*AMIPermissionFinding, len(owned))
+	g, ctx := errgroup.WithContext(a.ctx)
+	g.SetLimit(maxAttributeWorkers)
+	for i, img := range owned {
+		g.Go(func() error {
+			perms, err := launchPermissions(ctx, ec2Client, aws.ToString(img.ImageId))
+			if err != nil {
+				return err
+			}
+			// Each goroutine only writes its own slot
+			findings[i] = auditLaunchPermissions(img, perms, trusted)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	audit := &AMIPermissionAudit{Region: cfg.Region, OwnedCount: len(owned), Findings: []AMIPermissionFinding{}}
+	for _, f := range findings {
+		if f == nil {
+			continue
+		}
+		if f.Public {
+			audit.PublicCount++
+		}
+		if len(f.UnknownAccounts) > 0 {
+			audit.UnknownCount++
+		}
+		audit.Findings = append(audit.Findings, *f)
+	}
+	sort.Slice(audit.Findings, func(i, j int) bool {
+		return audit.Findings[i].Image.ID < audit.Findings[j].Image.ID
+	})
+	return audit, nil
+}
+
+// launchPermissions returns the launchPermission attribute of an image
+func launchPermissions(ctx context.Context, client *ec2.Client, imageID string) ([]ec2types.LaunchPermission, error) {
+	out, err := client.DescribeImageAttribute(ctx, &ec2.DescribeImageAttributeInput{
+		ImageId:   aws.String(imageID),
+		Attribute: ec2types.ImageAttributeNameLaunchPermission,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe launch permissions of %s: %w", imageID, err)
+	}
+	return out.LaunchPermissions, nil
+}
+
+// auditLaunchPermissions builds the finding of an image, nil when it is not shared
+func auditLaunchPermissions(img ec2types.Image, perms []ec2types.LaunchPermission, trusted map[string]bool) *AMIPermissionFinding {
+	if len(perms) == 0 {
+		return nil
+	}
+
+	f := &AMIPermissionFinding{
+		Image:           toAMIImage(img),
+		SharedWith:      []string{},
+		UnknownAccounts: []string{},
+		Organizations:   []string{},
+	}
+	for _, p := range perms {
+		switch {
+		case p.Group == ec2types.PermissionGroupAll:
+			f.Public = true
+		case p.UserId != nil:
+			f.SharedWith = append(f.SharedWith, *p.UserId)
+			if !trusted[*p.UserId] {
+				f.UnknownAccounts = append(f.UnknownAccounts, *p.UserId)
+			}
+		case p.OrganizationArn != nil:
+			f.Organizations = append(f.Organizations, *p.OrganizationArn)
+		case p.OrganizationalUnitArn != nil:
+			f.Organizations = append(f.Organizations, *p.OrganizationalUnitArn)
+		}
+	}
+	return f
+}
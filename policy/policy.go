@@ -0,0 +1,231 @@
+// Package policy evaluates YAML-defined rules against the instances of a scan.
+//
+// A policy file lists rules, each with one condition:
+//
+//	rules:
+//	  - name: ami-age
+//	    amiAgeDays: {warn: 90, fail: 180}
+//	  - name: required-tags
+//	    requiredTags: [Owner, CostCenter]
+//	  - name: no-deprecated-ami
+//	    amiStatus: [deprecated, deregistered]
+//	    states: [running]
+//	  - name: no-public-ami
+//	    publicAmi: true
+//	    severity: warn
+//
+// Teams with existing policy-as-code can also evaluate Rego policies, see Rego.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity of a rule result
+type Severity string
+
+const (
+	SeverityWarn Severity = "warn"
+	SeverityFail Severity = "fail"
+)
+
+// Policy is a set of rules
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule flags the instances matching its condition. Exactly one of AMIAgeDays,
+// RequiredTags, AMIStatus and PublicAMI must be set.
+type Rule struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Severity of the results of RequiredTags, AMIStatus and PublicAMI; fail by default.
+	// AMIAgeDays carries its own severities.
+	Severity Severity `yaml:"severity"`
+	// States restricts the rule to the instances in these states, e.g. running
+	States []string `yaml:"states"`
+
+	// AMIAgeDays warns and fails on AMIs older than its thresholds (0 skips a threshold)
+	AMIAgeDays *Thresholds `yaml:"amiAgeDays"`
+	// RequiredTags are the tags every instance must have, with a non-empty value
+	RequiredTags []string `yaml:"requiredTags"`
+	// AMIStatus are the forbidden AMI statuses: deprecated, deregistered
+	AMIStatus []string `yaml:"amiStatus"`
+	// PublicAMI flags the instances running an AMI anyone can launch
+	PublicAMI bool `yaml:"publicAmi"`
+}
+
+// Thresholds are ages in days
+type Thresholds struct {
+	Warn int `yaml:"warn"`
+	Fail int `yaml:"fail"`
+}
+
+// Instance is what the rules see of a scanned instance
+type Instance struct {
+	ID         string
+	Name       string
+	Region     string
+	State      string
+	AMI        string
+	AMIAgeDays int
+	AMIStatus  string
+	AMIPublic  bool
+	Tags       map[string]string
+}
+
+// Result is a rule matched by an instance
+type Result struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	InstanceID string   `json:"instanceId"`
+	Region     string   `json:"region"`
+	Message    string   `json:"message"`
+}
+
+// Load reads and validates a policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse decodes and validates a policy; unknown fields are rejected so typos don't
+// silently disable a rule
+func Parse(data []byte) (*Policy, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var p Policy
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that every rule is named, unique and has exactly one condition
+func (p *Policy) Validate() error {
+	names := make(map[string]bool, len(p.Rules))
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate rule %q", r.Name)
+		}
+		names[r.Name] = true
+
+		conditions := 0
+		if r.AMIAgeDays != nil {
+			conditions++
+			if r.AMIAgeDays.Warn < 0 || r.AMIAgeDays.Fail < 0 || (r.AMIAgeDays.Warn == 0 && r.AMIAgeDays.Fail == 0) {
+				return fmt.Errorf("rule %q: amiAgeDays needs a positive warn or fail threshold", r.Name)
+			}
+		}
+		if len(r.RequiredTags) > 0 {
+			conditions++
+		}
+		if len(r.AMIStatus) > 0 {
+			conditions++
+		}
+		if r.PublicAMI {
+			conditions++
+		}
+		if conditions != 1 {
+			return fmt.Errorf("rule %q must have exactly one of amiAgeDays, requiredTags, amiStatus, publicAmi", r.Name)
+		}
+		if r.Severity != "" && r.Severity != SeverityWarn && r.Severity != SeverityFail {
+			return fmt.Errorf("rule %q: unknown severity %q: must be %s or %s", r.Name, r.Severity, SeverityWarn, SeverityFail)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the results of every rule, rule by rule in the order of the policy
+func (p *Policy) Evaluate(instances []Instance) []Result {
+	results := []Result{}
+	for _, r := range p.Rules {
+		for _, inst := range instances {
+			if len(r.States) > 0 && !slices.Contains(r.States, inst.State) {
+				continue
+			}
+			if severity, msg, ok := r.match(inst); ok {
+				results = append(results, Result{
+					Rule:       r.Name,
+					Severity:   severity,
+					InstanceID: inst.ID,
+					Region:     inst.Region,
+					Message:    msg,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// match tells whether the instance breaks the rule, with the severity and explanation
+func (r Rule) match(inst Instance) (Severity, string, bool) {
+	severity := r.Severity
+	if severity == "" {
+		severity = SeverityFail
+	}
+
+	switch {
+	case r.AMIAgeDays != nil:
+		t := r.AMIAgeDays
+		if inst.AMI == "" || inst.AMIAgeDays == 0 {
+			return "", "", false
+		}
+		if t.Fail > 0 && inst.AMIAgeDays > t.Fail {
+			return SeverityFail, fmt.Sprintf("AMI %s is %d days old (fail above %d)", inst.AMI, inst.AMIAgeDays, t.Fail), true
+		}
+		if t.Warn > 0 && inst.AMIAgeDays > t.Warn {
+			return SeverityWarn, fmt.Sprintf("AMI %s is %d days old (warn above %d)", inst.AMI, inst.AMIAgeDays, t.Warn), true
+		}
+	case len(r.RequiredTags) > 0:
+		var missing []string
+		for _, tag := range r.RequiredTags {
+			if inst.Tags[tag] == "" {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			return severity, "missing tags: " + strings.Join(missing, ", "), true
+		}
+	case len(r.AMIStatus) > 0:
+		if slices.Contains(r.AMIStatus, inst.AMIStatus) {
+			return severity, fmt.Sprintf("AMI %s is %s", inst.AMI, inst.AMIStatus), true
+		}
+	case r.PublicAMI:
+		if inst.AMIPublic {
+			return severity, fmt.Sprintf("AMI %s is public", inst.AMI), true
+		}
+	}
+	return "", "", false
+}
+
+// Failed tells whether any result has the fail severity
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Severity == SeverityFail {
+			return true
+		}
+	}
+	return false
+}
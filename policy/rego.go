@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// RegoQuery is the rule the Rego policies define: a set or array of violations,
+// each a message string or an object with msg and optional rule, severity,
+// instanceId and region:
+//
+//	package gocheckami
+//
+//	deny contains {"msg": msg, "instanceId": i.instanceId} if {
+//	    some i in input.instances
+//	    not i.tags.Owner
+//	    msg := sprintf("%s has no Owner tag", [i.instanceId])
+//	}
+const RegoQuery = "data.gocheckami.deny"
+
+// regoTimeout bounds an opa eval run
+const regoTimeout = 30 * time.Second
+
+// regoRule names the results of violations that don't set a rule
+const regoRule = "rego"
+
+// Rego evaluates Rego policies with the opa binary, the OPA library being too
+// heavy a dependency for the app
+type Rego struct {
+	// Path is a .rego file or a directory of them
+	Path string
+	// OPA is the opa executable
+	OPA string
+}
+
+// NewRego checks the policy path and looks up opa on the PATH
+func NewRego(path string) (*Rego, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to read Rego policies: %w", err)
+	}
+	opa, err := exec.LookPath("opa")
+	if err != nil {
+		return nil, fmt.Errorf("opa is required to evaluate Rego policies: %w", err)
+	}
+	return &Rego{Path: path, OPA: opa}, nil
+}
+
+// regoViolation is a violation as defined by a policy, when it is an object
+type regoViolation struct {
+	Msg        string   `json:"msg"`
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	InstanceID string   `json:"instanceId"`
+	Region     string   `json:"region"`
+}
+
+// Evaluate runs RegoQuery with input, the scan result, and returns the violations
+// as results; violations are failures unless they set the warn severity
+func (r *Rego) Evaluate(ctx context.Context, input any) ([]Result, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Rego input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, regoTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, r.OPA, "eval", "--format", "json", "--stdin-input", "--data", r.Path, RegoQuery)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		return nil, fmt.Errorf("failed to evaluate Rego policies: %w: %s", err, msg)
+	}
+	return parseRegoOutput(stdout.Bytes())
+}
+
+// parseRegoOutput reads the violations from the output of opa eval --format json
+func parseRegoOutput(out []byte) ([]Result, error) {
+	var output struct {
+		Result []struct {
+			Expressions []struct {
+				Value []json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode opa output: %w", err)
+	}
+
+	results := []Result{}
+	for _, res := range output.Result {
+		for _, expr := range res.Expressions {
+			for _, raw := range expr.Value {
+				var v regoViolation
+				if err := json.Unmarshal(raw, &v.Msg); err != nil {
+					if err := json.Unmarshal(raw, &v); err != nil {
+						return nil, fmt.Errorf("invalid violation %s: must be a string or an object with msg", raw)
+					}
+				}
+				if v.Rule == "" {
+					v.Rule = regoRule
+				}
+				if v.Severity != SeverityWarn {
+					v.Severity = SeverityFail
+				}
+				results = append(results, Result{
+					Rule:       v.Rule,
+					Severity:   v.Severity,
+					InstanceID: v.InstanceID,
+					Region:     v.Region,
+					Message:    v.Msg,
+				})
+			}
+		}
+	}
+	return results, nil
+}
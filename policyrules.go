@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+
+	"goCheckAmi/policy"
+)
+
+// setPolicyFile loads the policy evaluated after each scan; an empty path removes it
+func (a *App) setPolicyFile(path string) error {
+	p, err := loadPolicyFile(path)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.policy = p
+	a.mu.Unlock()
+	return nil
+}
+
+// setRegoPolicy sets the Rego policies evaluated after each scan; an empty path removes them
+func (a *App) setRegoPolicy(path string) error {
+	r, err := loadRegoPolicy(path)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.rego = r
+	a.mu.Unlock()
+	return nil
+}
+
+// loadPolicyFile loads a policy file, nil for an empty path
+func loadPolicyFile(path string) (*policy.Policy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return policy.Load(path)
+}
+
+// loadRegoPolicy loads a Rego policy file or directory, nil for an empty path
+func loadRegoPolicy(path string) (*policy.Rego, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return policy.NewRego(path)
+}
+
+// ValidatePolicyFile checks a policy file without applying it
+func (a *App) ValidatePolicyFile(path string) error {
+	_, err := policy.Load(path)
+	return err
+}
+
+// regoService is the service of the scan error recorded when the Rego policies fail to evaluate
+const regoService = "rego"
+
+// applyPolicy evaluates the policies against the result, replacing the results of
+// a cached scan evaluated with previous policies. The Rego policies get the JSON of
+// the result as input; when they fail to evaluate, only the results of the policy
+// file are kept and the error is returned.
+func (a *App) applyPolicy(ctx context.Context, result *AWSResult) error {
+	a.mu.Lock()
+	p, rego := a.policy, a.rego
+	a.mu.Unlock()
+
+	result.PolicyResults = nil
+	var results []policy.Result
+	var err error
+	if rego != nil {
+		var violations []policy.Result
+		violations, err = rego.Evaluate(ctx, result)
+		results = append(results, violations...)
+	}
+	if p != nil {
+		results = append(p.Evaluate(policyInstances(result.Instances)), results...)
+	}
+	result.PolicyResults = results
+	return err
+}
+
+// policyInstances converts the instances for the policy rules
+func policyInstances(instances []EC2Instance) []policy.Instance {
+	converted := make([]policy.Instance, len(instances))
+	for i, inst := range instances {
+		converted[i] = policy.Instance{
+			ID:         inst.InstanceID,
+			Name:       inst.Name,
+			Region:     inst.Region,
+			State:      inst.State,
+			AMI:        inst.AMI,
+			AMIAgeDays: inst.AMIAgeDays,
+			AMIStatus:  inst.AMIStatus,
+			AMIPublic:  inst.AMIPublic,
+			Tags:       inst.Tags,
+		}
+	}
+	return converted
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UserPrefs holds the choices restored on the next launch
+type UserPrefs struct {
+	LastProfile string `json:"lastProfile"`
+	LastFilter  string `json:"lastFilter"`
+	LastRegion  string `json:"lastRegion"`
+	// Golden AMI allow-list used for the compliance check
+	GoldenAMIs         []string `json:"goldenAmis"`
+	GoldenAMIParameter string   `json:"goldenAmiParameter"`
+	// AWSConfigFile and AWSCredentialsFile replace ~/.aws/config and ~/.aws/credentials
+	// (and the AWS_CONFIG_FILE / AWS_SHARED_CREDENTIALS_FILE variables) when set
+	AWSConfigFile      string `json:"awsConfigFile"`
+	AWSCredentialsFile string `json:"awsCredentialsFile"`
+	// CacheTTLMinutes is how long a scan is served from the result cache:
+	// 0 keeps the default of an hour, a negative value disables the cache
+	CacheTTLMinutes int `json:"cacheTtlMinutes"`
+	// PolicyFile is the YAML policy evaluated after each scan, none when empty
+	PolicyFile string `json:"policyFile"`
+	// RegoPolicyPath is a .rego file or directory evaluated with opa after each scan
+	RegoPolicyPath string `json:"regoPolicyPath"`
+	// SlackWebhookURL receives a summary of each scheduled scan, when set
+	SlackWebhookURL string `json:"slackWebhookUrl"`
+	// EmailTo receive the HTML report of each scheduled scan, sent through SES from
+	// EmailFrom (a verified identity) with the credentials and region of EmailProfile
+	EmailProfile string   `json:"emailProfile"`
+	EmailFrom    string   `json:"emailFrom"`
+	EmailTo      []string `json:"emailTo"`
+	// SNSTopicARN receives the JSON of each scheduled scan, or only its violations
+	// with SNSViolationsOnly, published with the credentials of SNSProfile
+	SNSTopicARN       string `json:"snsTopicArn"`
+	SNSProfile        string `json:"snsProfile"`
+	SNSViolationsOnly bool   `json:"snsViolationsOnly"`
+	// LogLevel is debug, info (default), warn or error
+	LogLevel string `json:"logLevel"`
+	// RetryMode is standard (default) or adaptive; zero attempts and backoff keep
+	// the SDK defaults (3 attempts, 20s)
+	RetryMode              string `json:"retryMode"`
+	RetryMaxAttempts       int    `json:"retryMaxAttempts"`
+	RetryMaxBackoffSeconds int    `json:"retryMaxBackoffSeconds"`
+	// OperationTimeoutSeconds bounds each API call, retries included, 0 for no
+	// timeout; OperationTimeouts override it by operation name, e.g. DescribeImages
+	OperationTimeoutSeconds int            `json:"operationTimeoutSeconds"`
+	OperationTimeouts       map[string]int `json:"operationTimeouts"`
+	// MaxRequestsPerSecond caps the DescribeInstances, DescribeImages and
+	// DescribeParameters calls of every scan, 0 for no cap
+	MaxRequestsPerSecond float64 `json:"maxRequestsPerSecond"`
+	// DemoMode replaces the AWS profiles with a demo account of synthetic data
+	DemoMode bool `json:"demoMode"`
+}
+
+// prefsPath returns the location of prefs.json under the OS config dir
+func prefsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+	return filepath.Join(dir, "goCheckAmi", "prefs.json"), nil
+}
+
+// SaveUserPrefs writes the preferences to disk, creating the directory if needed,
+// and applies the log level, retry settings, rate limit, shared config files, result
+// cache TTL, policies, notifiers and demo mode they set. Every setting is validated
+// first: an invalid one is an error, and nothing is applied or saved.
+func (a *App) SaveUserPrefs(prefs UserPrefs) error {
+	level, err := parseLogLevel(prefs.LogLevel)
+	if err != nil {
+		return err
+	}
+	retrySettings, err := parseRetryPrefs(prefs)
+	if err != nil {
+		return err
+	}
+	if err := validateRateLimit(prefs.MaxRequestsPerSecond); err != nil {
+		return err
+	}
+	p, err := loadPolicyFile(prefs.PolicyFile)
+	if err != nil {
+		return err
+	}
+	rego, err := loadRegoPolicy(prefs.RegoPolicyPath)
+	if err != nil {
+		return err
+	}
+	notifiers, err := a.newNotifiers(prefs)
+	if err != nil {
+		return err
+	}
+
+	logLevel.Set(level)
+	a.applyRetrySettings(retrySettings)
+	_ = a.setRateLimit(prefs.MaxRequestsPerSecond) // validated above
+	a.mu.Lock()
+	a.policy, a.rego, a.notifiers = p, rego, notifiers
+	a.mu.Unlock()
+	a.setDemoMode(prefs.DemoMode)
+	a.setAWSFiles(prefs.AWSConfigFile, prefs.AWSCredentialsFile)
+	a.setResultCacheTTL(prefs.CacheTTLMinutes)
+
+	path, err := prefsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create prefs dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode prefs: %w", err)
+	}
+	// The Slack webhook URL is a secret: only the user may read the file
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write prefs: %w", err)
+	}
+	// WriteFile keeps the mode of a file written by an older version
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("failed to restrict prefs permissions: %w", err)
+	}
+	return nil
+}
+
+// LoadUserPrefs reads the saved preferences.
+// A missing file yields the zero value; a corrupt one yields the zero value plus a warning error.
+func (a *App) LoadUserPrefs() (UserPrefs, error) {
+	var prefs UserPrefs
+
+	path, err := prefsPath()
+	if err != nil {
+		return prefs, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, fmt.Errorf("failed to read prefs: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return UserPrefs{}, fmt.Errorf("ignoring corrupt prefs file %s: %w", path, err)
+	}
+	return prefs, nil
+}
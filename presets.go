@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// presetsBucket holds the saved searches, keyed by name
+var presetsBucket = []byte("presets")
+
+// Preset is a named search: the profile, regions, filters and checks of a scan
+type Preset struct {
+	Name    string            `json:"name"`
+	Request ProcessingRequest `json:"request"`
+	// Schedule is a cron expression (five fields or @daily, @every 6h...) to run the
+	// search in the background; empty runs it only on demand
+	Schedule string `json:"schedule"`
+}
+
+// SavePreset stores a preset, replacing the one with the same name, and (re)schedules it
+func (a *App) SavePreset(preset Preset) error {
+	preset.Name = strings.TrimSpace(preset.Name)
+	if preset.Name == "" {
+		return fmt.Errorf("preset name is required")
+	}
+	if preset.Request.Profile == "" {
+		return fmt.Errorf("preset %q has no profile", preset.Name)
+	}
+	preset.Schedule = strings.TrimSpace(preset.Schedule)
+	if preset.Schedule != "" {
+		if err := validateSchedule(preset.Schedule); err != nil {
+			return err
+		}
+	}
+	// Per-run flags are not part of the search
+	preset.Request.RequestID = ""
+	preset.Request.Refresh, preset.Request.ForceRefresh = false, false
+
+	db, err := a.db()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(preset)
+	if err != nil {
+		return fmt.Errorf("failed to encode preset: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(presetsBucket).Put([]byte(preset.Name), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save preset %q: %w", preset.Name, err)
+	}
+	a.schedulePreset(preset)
+	return nil
+}
+
+// ListPresets returns the saved presets, sorted by name
+func (a *App) ListPresets() ([]Preset, error) {
+	db, err := a.db()
+	if err != nil {
+		return nil, err
+	}
+	presets := []Preset{}
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(presetsBucket).ForEach(func(k, v []byte) error {
+			var p Preset
+			if err := json.Unmarshal(v, &p); err != nil {
+				slog.Warn("Unable to decode preset", "name", string(k), "err", err)
+				return nil
+			}
+			presets = append(presets, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list presets: %w", err)
+	}
+	return presets, nil
+}
+
+// GetPreset returns the preset with the given name
+func (a *App) GetPreset(name string) (*Preset, error) {
+	db, err := a.db()
+	if err != nil {
+		return nil, err
+	}
+	var preset *Preset
+	err = db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(presetsBucket).Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		preset = &Preset{}
+		return json.Unmarshal(data, preset)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset %q: %w", name, err)
+	}
+	if preset == nil {
+		return nil, fmt.Errorf("preset %q not found", name)
+	}
+	return preset, nil
+}
+
+// DeletePreset removes a saved preset
+func (a *App) DeletePreset(name string) error {
+	db, err := a.db()
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(presetsBucket).Delete([]byte(name))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete preset %q: %w", name, err)
+	}
+	a.unschedulePreset(name)
+	return nil
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	pricingtypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// The Pricing API is only served from a few regions; us-east-1 knows every region's prices
+const pricingRegion = "us-east-1"
+
+// hoursPerMonth is the usual AWS approximation of a month
+const hoursPerMonth = 730
+
+// priceCacheTTL is how long a cached on-demand price is trusted
+const priceCacheTTL = 7 * 24 * time.Hour
+
+// cachedPrice is an on-demand hourly price kept in pricing.json
+type cachedPrice struct {
+	HourlyUSD float64   `json:"hourlyUsd"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// priceCache is the on-disk cache of on-demand prices, keyed by region/type/os
+type priceCache struct {
+	mu     sync.Mutex
+	loaded bool
+	prices map[string]cachedPrice
+}
+
+// pricingCachePath returns the location of pricing.json under the OS config dir
+func pricingCachePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+	return filepath.Join(dir, "goCheckAmi", "pricing.json"), nil
+}
+
+// get returns a fresh cached price, loading the cache file on first use
+func (c *priceCache) get(key string) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loaded {
+		c.loaded = true
+		c.prices = make(map[string]cachedPrice)
+		if path, err := pricingCachePath(); err == nil {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				if err := json.Unmarshal(data, &c.prices); err != nil {
+					slog.Warn("Ignoring corrupt pricing cache", "path", path, "err", err)
+					c.prices = make(map[string]cachedPrice)
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				slog.Warn("Unable to read pricing cache", "err", err)
+			}
+		}
+	}
+
+	p, ok := c.prices[key]
+	if !ok || time.Since(p.FetchedAt) > priceCacheTTL {
+		return 0, false
+	}
+	return p.HourlyUSD, true
+}
+
+// put stores a price in memory; save writes the cache to disk
+func (c *priceCache) put(key string, hourly float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prices[key] = cachedPrice{HourlyUSD: hourly, FetchedAt: time.Now()}
+}
+
+// save writes the cache to pricing.json. The lock is held until the file is in place,
+// so the region and profile workers saving at the same time write it one after the
+// other, and the file is replaced by a rename so it is never left half written.
+func (c *priceCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c.prices, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pricing cache: %w", err)
+	}
+
+	path, err := pricingCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create pricing cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "pricing-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write pricing cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write pricing cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write pricing cache: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write pricing cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write pricing cache: %w", err)
+	}
+	return nil
+}
+
+// pricingOperatingSystem maps the EC2 platform details to the Pricing API operatingSystem
+func pricingOperatingSystem(platform string) string {
+	switch {
+	case strings.HasPrefix(platform, "Windows"):
+		return "Windows"
+	case strings.HasPrefix(platform, "Red Hat"):
+		return "RHEL"
+	case strings.HasPrefix(platform, "SUSE"):
+		return "SUSE"
+	default:
+		return "Linux"
+	}
+}
+
+// estimateCosts attaches the on-demand price of each running instance, based on its
+// type, region and operating system, and returns the estimated monthly total.
+// Spot and stopped instances are left at 0: their compute isn't billed on-demand.
+func (a *App) estimateCosts(ctx context.Context, cfg aws.Config, instances []EC2Instance) (float64, error) {
+	client := pricing.NewFromConfig(cfg, func(o *pricing.Options) {
+		o.Region = pricingRegion
+	})
+	var total float64
+	var firstErr error
+	fetched := false
+	failed := make(map[string]bool)
+
+	for i := range instances {
+		inst := &instances[i]
+		if inst.InstanceType == "" || inst.Lifecycle != LifecycleOnDemand || (inst.State != "running" && inst.State != "pending") {
+			continue
+		}
+
+		opSys := pricingOperatingSystem(inst.Platform)
+		key := inst.Region + "/" + inst.InstanceType + "/" + opSys
+		if failed[key] {
+			continue
+		}
+		hourly, ok := a.prices.get(key)
+		if !ok {
+			var err error
+			hourly, err = onDemandPrice(ctx, client, inst.Region, inst.InstanceType, opSys)
+			if err != nil {
+				// Keep pricing the other types, report the first failure
+				if firstErr == nil {
+					firstErr = err
+				}
+				failed[key] = true
+				continue
+			}
+			a.prices.put(key, hourly)
+			fetched = true
+		}
+		inst.HourlyCostUSD = hourly
+		inst.MonthlyCostUSD = hourly * hoursPerMonth
+		total += inst.MonthlyCostUSD
+	}
+
+	if fetched {
+		if err := a.prices.save(); err != nil {
+			slog.Warn("Unable to save pricing cache", "err", err)
+		}
+	}
+	return total, firstErr
+}
+
+// priceListProduct is the part of a price list entry holding the on-demand price
+type priceListProduct struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// onDemandPrice asks the Pricing API for the hourly on-demand price of an instance type
+func onDemandPrice(ctx context.Context, client *pricing.Client, region, instanceType, operatingSystem string) (float64, error) {
+	filter := func(field, value string) pricingtypes.Filter {
+		return pricingtypes.Filter{Type: pricingtypes.FilterTypeTermMatch, Field: aws.String(field), Value: aws.String(value)}
+	}
+	pager := pricing.NewGetProductsPaginator(client, &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []pricingtypes.Filter{
+			filter("regionCode", region),
+			filter("instanceType", instanceType),
+			filter("operatingSystem", operatingSystem),
+			filter("tenancy", "Shared"),
+			filter("preInstalledSw", "NA"),
+			filter("capacitystatus", "Used"),
+			filter("licenseModel", "No License required"),
+		},
+		FormatVersion: aws.String("aws_v1"),
+		MaxResults:    aws.Int32(1),
+	})
+
+	// The first product matching the filters is enough; pages may come back empty
+	var priceList string
+	for priceList == "" && pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get price of %s in %s: %w", instanceType, region, err)
+		}
+		if len(page.PriceList) > 0 {
+			priceList = page.PriceList[0]
+		}
+	}
+	if priceList == "" {
+		return 0, fmt.Errorf("no on-demand price for %s (%s) in %s", instanceType, operatingSystem, region)
+	}
+
+	var product priceListProduct
+	if err := json.Unmarshal([]byte(priceList), &product); err != nil {
+		return 0, fmt.Errorf("failed to decode price list of %s: %w", instanceType, err)
+	}
+	for _, term := range product.Terms.OnDemand {
+		for _, dim := range term.PriceDimensions {
+			if usd, ok := dim.PricePerUnit["USD"]; ok {
+				price, err := strconv.ParseFloat(usd, 64)
+				if err != nil {
+					return 0, fmt.Errorf("invalid price %q for %s: %w", usd, instanceType, err)
+				}
+				return price, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no USD on-demand price for %s in %s", instanceType, region)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// profileNamePattern are the profile names the editor accepts: no spaces or brackets,
+// which would break the section header
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.@+-]+$`)
+
+// regionPattern matches the AWS region names, e.g. us-east-1 or us-gov-west-1
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// ProfileInput holds the settings the profile editor manages.
+// Saving an empty field removes the key from the profile.
+type ProfileInput struct {
+	Name          string `json:"name"`
+	Region        string `json:"region"`
+	SSOSession    string `json:"ssoSession"`
+	EndpointURL   string `json:"endpointUrl"`
+	RoleARN       string `json:"roleArn"`
+	SourceProfile string `json:"sourceProfile"`
+}
+
+// settings returns the keys of the input in the order they are written
+func (p ProfileInput) settings() [][2]string {
+	return [][2]string{
+		{"region", p.Region},
+		{"sso_session", p.SSOSession},
+		{"endpoint_url", p.EndpointURL},
+		{"role_arn", p.RoleARN},
+		{"source_profile", p.SourceProfile},
+	}
+}
+
+// GetProfileSettings returns the editable settings of a profile, to prefill the editor
+func (a *App) GetProfileSettings(name string) (ProfileInput, error) {
+	cfg, err := a.loadAWSConfigFile()
+	if err != nil {
+		return ProfileInput{}, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	section, err := cfg.GetSection(profileSectionName(name))
+	if err != nil {
+		return ProfileInput{}, fmt.Errorf("profile %q not found", name)
+	}
+	return ProfileInput{
+		Name:          name,
+		Region:        section.Key("region").String(),
+		SSOSession:    section.Key("sso_session").String(),
+		EndpointURL:   section.Key("endpoint_url").String(),
+		RoleARN:       section.Key("role_arn").String(),
+		SourceProfile: section.Key("source_profile").String(),
+	}, nil
+}
+
+// SaveProfile creates or updates a profile in the shared config file. The file is
+// edited line by line so comments and other sections are kept as they are, and the
+// original is first copied next to it; the backup path is returned ("" for a new file).
+func (a *App) SaveProfile(input ProfileInput) (string, error) {
+	if err := a.validateProfileInput(input); err != nil {
+		return "", err
+	}
+
+	path := a.awsConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read aws config: %w", err)
+	}
+	mode := os.FileMode(0o600)
+	backup := ""
+	if err == nil {
+		if info, statErr := os.Stat(path); statErr == nil {
+			mode = info.Mode().Perm()
+		}
+		backup = path + ".bak." + time.Now().Format("20060102-150405")
+		if err := os.WriteFile(backup, data, mode); err != nil {
+			return "", fmt.Errorf("failed to back up aws config: %w", err)
+		}
+	} else if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create aws config dir: %w", err)
+	}
+
+	updated := setProfileSettings(string(data), profileSectionName(input.Name), input.settings())
+	if err := os.WriteFile(path, []byte(updated), mode); err != nil {
+		return "", fmt.Errorf("failed to write aws config: %w", err)
+	}
+	a.InvalidateProfile(input.Name)
+	return backup, nil
+}
+
+// validateProfileInput rejects settings the SDK would fail on later with a vaguer error.
+// A line break in a value would add keys to the profile, such as a credential_process
+// the SDK runs, so no value may contain control characters.
+func (a *App) validateProfileInput(input ProfileInput) error {
+	if !profileNamePattern.MatchString(input.Name) {
+		return fmt.Errorf("invalid profile name %q", input.Name)
+	}
+	for _, s := range input.settings() {
+		if strings.ContainsFunc(s[1], unicode.IsControl) {
+			return fmt.Errorf("invalid %s %q: control characters are not allowed", s[0], s[1])
+		}
+	}
+	if input.Region != "" && !regionPattern.MatchString(input.Region) {
+		return fmt.Errorf("invalid region %q", input.Region)
+	}
+	if input.EndpointURL != "" {
+		u, err := url.Parse(input.EndpointURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid endpoint_url %q: expected http(s)://host[:port]", input.EndpointURL)
+		}
+	}
+	if input.RoleARN != "" {
+		if !strings.HasPrefix(input.RoleARN, "arn:") || !strings.Contains(input.RoleARN, ":role/") {
+			return fmt.Errorf("invalid role_arn %q", input.RoleARN)
+		}
+		if input.SourceProfile == "" {
+			return fmt.Errorf("role_arn needs a source_profile")
+		}
+	}
+	if input.SSOSession != "" {
+		cfg, err := a.loadAWSConfigFile()
+		if err != nil {
+			return fmt.Errorf("failed to load aws config: %w", err)
+		}
+		if _, err := cfg.GetSection("sso-session " + input.SSOSession); err != nil {
+			return fmt.Errorf("sso-session %q not found", input.SSOSession)
+		}
+	}
+	return nil
+}
+
+// profileSectionName is the config file section of a profile
+func profileSectionName(name string) string {
+	if name == "default" {
+		return "default"
+	}
+	return "profile " + name
+}
+
+// setProfileSettings sets the keys of a section in the text of a config file,
+// removing keys with an empty value and appending the section when missing
+func setProfileSettings(text, section string, settings [][2]string) string {
+	lines := strings.Split(text, "\n")
+	if text == "" {
+		lines = nil
+	}
+
+	start := -1
+	for i, line := range lines {
+		if sectionHeader(line) == section {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		var added []string
+		for _, kv := range settings {
+			if kv[1] != "" {
+				added = append(added, kv[0]+" = "+kv[1])
+			}
+		}
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]")
+		lines = append(lines, added...)
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if sectionHeader(lines[i]) != "" {
+			end = i
+			break
+		}
+	}
+	body := append([]string{}, lines[start+1:end]...)
+
+	for _, kv := range settings {
+		key, value := kv[0], kv[1]
+		found := -1
+		for i, line := range body {
+			if settingKey(line) == key {
+				found = i
+				break
+			}
+		}
+		switch {
+		case found >= 0 && value == "":
+			body = append(body[:found], body[found+1:]...)
+		case found >= 0:
+			body[found] = key + " = " + value
+		case value != "":
+			// Insert after the last setting, before the blank lines separating sections
+			at := len(body)
+			for at > 0 && strings.TrimSpace(body[at-1]) == "" {
+				at--
+			}
+			body = append(body[:at], append([]string{key + " = " + value}, body[at:]...)...)
+		}
+	}
+
+	out := append([]string{}, lines[:start+1]...)
+	out = append(out, body...)
+	out = append(out, lines[end:]...)
+	return strings.Join(out, "\n")
+}
+
+// sectionHeader returns the name of a "[name]" line, "" for other lines
+func sectionHeader(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "[") || !strings.HasSuffix(trimmed, "]") {
+		return ""
+	}
+	return strings.Join(strings.Fields(trimmed[1:len(trimmed)-1]), " ")
+}
+
+// settingKey returns the key of a top-level "key = value" line; indented lines
+// are nested settings (services sections) and are ignored
+func settingKey(line string) string {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return ""
+	}
+	key, _, ok := strings.Cut(line, "=")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"gopkg.in/ini.v1"
+)
+
+// Profile kinds, by how the profile gets its credentials
+const (
+	ProfileKindSSO       = "sso"
+	ProfileKindRole      = "role"
+	ProfileKindAccessKey = "access-key"
+	ProfileKindProcess   = "credential-process"
+	ProfileKindUnknown   = "unknown"
+)
+
+// ProfileInfo describes a profile of the shared config files for the profile picker
+type ProfileInfo struct {
+	Name string `json:"name"`
+	// Kind is sso, role, access-key, credential-process or unknown
+	Kind   string `json:"kind"`
+	Region string `json:"region"`
+	// EndpointURL is the profile-level endpoint_url, e.g. LocalStack
+	EndpointURL string `json:"endpointUrl"`
+	Output      string `json:"output"`
+	// SSO is the Identity Center login of SSO-backed profiles
+	SSO *SSOSession `json:"sso,omitempty"`
+}
+
+// profileInfo builds the info of a profile from its section; creds is the
+// shared credentials file, nil when it can't be read
+func (a *App) profileInfo(name string, section *ini.Section, creds *ini.File) ProfileInfo {
+	info := ProfileInfo{
+		Name:        name,
+		Kind:        ProfileKindUnknown,
+		Region:      section.Key("region").String(),
+		EndpointURL: section.Key("endpoint_url").String(),
+		Output:      section.Key("output").String(),
+	}
+
+	s, sso := a.profileSSOSettings(name)
+	if sso {
+		info.SSO = &SSOSession{Profile: name, Name: s.session, StartURL: s.startURL, Region: s.region}
+	}
+
+	switch {
+	case section.HasKey("role_arn"):
+		info.Kind = ProfileKindRole
+	case sso:
+		info.Kind = ProfileKindSSO
+	case section.HasKey("credential_process"):
+		info.Kind = ProfileKindProcess
+	case section.HasKey("aws_access_key_id"),
+		creds != nil && creds.Section(name).HasKey("aws_access_key_id"):
+		info.Kind = ProfileKindAccessKey
+	}
+	return info
+}
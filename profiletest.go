@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+// profileTestTimeout bounds TestProfile, so a dead endpoint doesn't stall the picker.
+// A longer configured timeout of the endpoint probe wins.
+const profileTestTimeout = 5 * time.Second
+
+// Profile test statuses
+const (
+	ProfileStatusOK          = "ok"
+	ProfileStatusExpired     = "expired"
+	ProfileStatusUnreachable = "unreachable"
+	ProfileStatusMFARequired = "mfa-required"
+	ProfileStatusError       = "error"
+)
+
+// expiredCredentialCodes are the STS errors of expired or revoked credentials
+var expiredCredentialCodes = map[string]bool{
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"InvalidClientTokenId":        true,
+	"UnrecognizedClientException": true,
+	"RequestExpired":              true,
+}
+
+// ProfileTestResult is the outcome of TestProfile
+type ProfileTestResult struct {
+	Profile string `json:"profile"`
+	// Status is ok, expired, unreachable, mfa-required or error
+	Status    string `json:"status"`
+	AccountID string `json:"accountId,omitempty"`
+	ARN       string `json:"arn,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// TestProfile loads the profile and calls GetCallerIdentity with a short timeout.
+// Unlike a scan it never starts an SSO login or asks for an MFA code.
+func (a *App) TestProfile(profile string) (*ProfileTestResult, error) {
+	res := &ProfileTestResult{Profile: profile}
+	ctx, cancel := context.WithTimeout(a.ctx, max(profileTestTimeout, a.endpointProbeTimeout()))
+	defer cancel()
+
+	if demo := a.demoData(); demo != nil {
+		identity, _ := demo.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		res.Status = ProfileStatusOK
+		res.AccountID = aws.ToString(identity.Account)
+		res.ARN = aws.ToString(identity.Arn)
+		return res, nil
+	}
+	if cached := a.cachedConfigFor(ctx, profile); cached != nil {
+		res.Status = ProfileStatusOK
+		res.AccountID = aws.ToString(cached.identity.Account)
+		res.ARN = aws.ToString(cached.identity.Arn)
+		return res, nil
+	}
+	if a.profileRequiresMFA(profile) {
+		res.Status = ProfileStatusMFARequired
+		return res, nil
+	}
+
+	cfg, endpointURL, err := a.loadConfig(ctx, profile)
+	if err != nil {
+		res.Status, res.Message = ProfileStatusError, err.Error()
+		return res, nil
+	}
+	if endpointURL != "" {
+		if err := a.probeEndpoint(ctx, endpointURL); err != nil {
+			res.Status, res.Message = ProfileStatusUnreachable, err.Error()
+			return res, nil
+		}
+	}
+
+	identity, err := a.clientBackend().STS(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		res.Status, res.Message = profileErrorStatus(err), err.Error()
+		return res, nil
+	}
+	res.Status = ProfileStatusOK
+	res.AccountID = aws.ToString(identity.Account)
+	res.ARN = aws.ToString(identity.Arn)
+	return res, nil
+}
+
+// profileErrorStatus classifies a GetCallerIdentity failure
+func profileErrorStatus(err error) string {
+	var tokenErr *ssocreds.InvalidTokenError
+	var apiErr smithy.APIError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &tokenErr):
+		return ProfileStatusExpired
+	case errors.As(err, &apiErr) && expiredCredentialCodes[apiErr.ErrorCode()]:
+		return ProfileStatusExpired
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return ProfileStatusUnreachable
+	}
+	return ProfileStatusError
+}
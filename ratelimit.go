@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedOperations are the calls a scan makes per region and per page,
+// the ones that trip the account-level throttling on large scans
+var rateLimitedOperations = map[string]bool{
+	"DescribeInstances":  true,
+	"DescribeImages":     true,
+	"DescribeParameters": true,
+}
+
+// setRateLimit caps the rate of the rate-limited operations, shared by every
+// profile and region, allowing bursts of up to a second of requests. 0 removes the cap.
+func (a *App) setRateLimit(requestsPerSecond float64) error {
+	if err := validateRateLimit(requestsPerSecond); err != nil {
+		return err
+	}
+	if requestsPerSecond == 0 {
+		a.rateLimiter.SetLimit(rate.Inf)
+		return nil
+	}
+	a.rateLimiter.SetLimit(rate.Limit(requestsPerSecond))
+	a.rateLimiter.SetBurst(max(1, int(requestsPerSecond)))
+	return nil
+}
+
+// validateRateLimit checks a requests per second setting
+func validateRateLimit(requestsPerSecond float64) error {
+	if requestsPerSecond < 0 || math.IsNaN(requestsPerSecond) {
+		return fmt.Errorf("requests per second must not be negative, got %v", requestsPerSecond)
+	}
+	return nil
+}
+
+// rateLimitLoadOption makes every attempt of the rate-limited operations, retries
+// included, wait for a token of the app's bucket
+func (a *App) rateLimitLoadOption() config.LoadOptionsFunc {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("RateLimit", a.waitRateLimit), "Retry", middleware.After)
+		},
+	})
+}
+
+func (a *App) waitRateLimit(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	if rateLimitedOperations[awsmiddleware.GetOperationName(ctx)] {
+		if err := a.rateLimiter.Wait(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("failed to wait for the rate limit: %w", err)
+		}
+	}
+	return next.HandleFinalize(ctx, in)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// rdsService is the key of the RDS resources in the services section
+const rdsService = "rds"
+
+// engineWarningDays is how long before the end of standard support an engine version is flagged
+const engineWarningDays = 180
+
+// rdsStandardSupportEnd is the end of RDS standard support per engine and major version;
+// past it, RDS Extended Support charges apply.
+// See https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/extended-support.html
+var rdsStandardSupportEnd = map[string]map[string]string{
+	"mysql": {
+		"5.7": "2024-02-29",
+		"8.0": "2026-07-31",
+	},
+	"postgres": {
+		"11": "2024-02-29",
+		"12": "2025-02-28",
+		"13": "2026-02-28",
+		"14": "2027-02-28",
+		"15": "2028-02-29",
+		"16": "2029-02-28",
+	},
+	"aurora-mysql": {
+		"5.7": "2024-10-31",
+		"8.0": "2028-04-30",
+	},
+	"aurora-postgresql": {
+		"11": "2024-02-29",
+		"12": "2025-02-28",
+		"13": "2026-02-28",
+		"14": "2027-02-28",
+		"15": "2028-02-29",
+		"16": "2029-02-28",
+	},
+}
+
+// scanRDS lists the DB clusters and the DB instances outside clusters, flagging
+// engine versions past or near their end of standard support
+func (a *App) scanRDS(ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error) {
+	client := rds.NewFromConfig(cfg)
+	resources := []ServiceResource{}
+	now := time.Now()
+
+	clusters := rds.NewDescribeDBClustersPaginator(client, &rds.DescribeDBClustersInput{})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe db clusters: %w", err)
+		}
+		for _, c := range page.DBClusters {
+			engine, version := aws.ToString(c.Engine), aws.ToString(c.EngineVersion)
+			r := ServiceResource{Service: rdsService, Type: "db-cluster", ID: aws.ToString(c.DBClusterIdentifier),
+				ARN: aws.ToString(c.DBClusterArn), Region: cfg.Region, Version: version, Status: aws.ToString(c.Status),
+				Details: map[string]string{"engine": engine}}
+			r.Flagged, r.Finding = engineSupportFinding(engine, version, now)
+			resources = append(resources, r)
+		}
+	}
+
+	instances := rds.NewDescribeDBInstancesPaginator(client, &rds.DescribeDBInstancesInput{})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe db instances: %w", err)
+		}
+		for _, i := range page.DBInstances {
+			// Cluster members run the cluster's engine version, already reported
+			if aws.ToString(i.DBClusterIdentifier) != "" {
+				continue
+			}
+			engine, version := aws.ToString(i.Engine), aws.ToString(i.EngineVersion)
+			r := ServiceResource{Service: rdsService, Type: "db-instance", ID: aws.ToString(i.DBInstanceIdentifier),
+				ARN: aws.ToString(i.DBInstanceArn), Region: cfg.Region, Version: version, Status: aws.ToString(i.DBInstanceStatus),
+				Details: map[string]string{"engine": engine, "class": aws.ToString(i.DBInstanceClass)}}
+			r.Flagged, r.Finding = engineSupportFinding(engine, version, now)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// engineMajorVersion returns the major version used by rdsStandardSupportEnd:
+// "8.0" for MySQL 8.0.35 or Aurora MySQL 8.0.mysql_aurora.3.05.2, "15" for PostgreSQL 15.4
+func engineMajorVersion(engine, version string) string {
+	parts := strings.Split(version, ".")
+	if strings.Contains(engine, "postgres") {
+		if parts[0] == "9" && len(parts) > 1 {
+			return parts[0] + "." + parts[1]
+		}
+		return parts[0]
+	}
+	if len(parts) > 1 {
+		return parts[0] + "." + parts[1]
+	}
+	return parts[0]
+}
+
+// engineSupportFinding flags engine versions past their end of standard support,
+// or within engineWarningDays of it
+func engineSupportFinding(engine, version string, now time.Time) (bool, string) {
+	date, ok := rdsStandardSupportEnd[engine][engineMajorVersion(engine, version)]
+	if !ok {
+		return false, ""
+	}
+	end, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return false, ""
+	}
+	switch {
+	case !now.Before(end):
+		return true, fmt.Sprintf("standard support ended on %s", date)
+	case end.Sub(now) <= engineWarningDays*24*time.Hour:
+		return true, fmt.Sprintf("standard support ends on %s", date)
+	default:
+		return false, ""
+	}
+}
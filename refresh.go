@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+// actionInstanceRefresh is the confirmation action of StartInstanceRefresh
+const actionInstanceRefresh = "instance-refresh"
+
+// refreshPollInterval is how often a running instance refresh is polled
+const refreshPollInterval = 15 * time.Second
+
+// refreshDoneStatuses are the statuses after which an instance refresh no longer changes
+var refreshDoneStatuses = map[autoscalingtypes.InstanceRefreshStatus]bool{
+	autoscalingtypes.InstanceRefreshStatusSuccessful:         true,
+	autoscalingtypes.InstanceRefreshStatusFailed:             true,
+	autoscalingtypes.InstanceRefreshStatusCancelled:          true,
+	autoscalingtypes.InstanceRefreshStatusRollbackSuccessful: true,
+	autoscalingtypes.InstanceRefreshStatusRollbackFailed:     true,
+}
+
+// InstanceRefreshProgress is emitted as "refresh:progress" while an instance refresh runs
+type InstanceRefreshProgress struct {
+	AutoScalingGroup   string `json:"autoScalingGroup"`
+	InstanceRefreshID  string `json:"instanceRefreshId"`
+	Status             string `json:"status"`
+	StatusReason       string `json:"statusReason"`
+	PercentageComplete int    `json:"percentageComplete"`
+	InstancesToUpdate  int    `json:"instancesToUpdate"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ConfirmInstanceRefresh returns the token to pass to StartInstanceRefresh.
+// It is valid once, for that Auto Scaling group only.
+func (a *App) ConfirmInstanceRefresh(asgName string) (string, error) {
+	return a.issueConfirmation(actionInstanceRefresh, asgName)
+}
+
+// StartInstanceRefresh starts an instance refresh of an Auto Scaling group, typically
+// after UpdateLaunchTemplateAMI, and returns its ID. The rollout is then polled and
+// reported as "refresh:progress" events until it ends.
+// minHealthyPercentage is left to the group's default when 0.
+func (a *App) StartInstanceRefresh(profile string, asgName string, token string, minHealthyPercentage int) (string, error) {
+	if err := a.checkWriteAccess(token, actionInstanceRefresh, asgName); err != nil {
+		return "", err
+	}
+
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return "", err
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	in := &autoscaling.StartInstanceRefreshInput{AutoScalingGroupName: aws.String(asgName)}
+	if minHealthyPercentage > 0 {
+		in.Preferences = &autoscalingtypes.RefreshPreferences{MinHealthyPercentage: aws.Int32(int32(minHealthyPercentage))}
+	}
+	out, err := client.StartInstanceRefresh(a.ctx, in)
+	if err != nil {
+		return "", fmt.Errorf("failed to start instance refresh of %s: %w", asgName, err)
+	}
+
+	refreshID := aws.ToString(out.InstanceRefreshId)
+	go a.pollInstanceRefresh(a.ctx, client, asgName, refreshID)
+	return refreshID, nil
+}
+
+// pollInstanceRefresh emits the progress of an instance refresh until it is done
+// or the app shuts down. A failing poll is reported once and ends the polling.
+func (a *App) pollInstanceRefresh(ctx context.Context, client *autoscaling.Client, asgName, refreshID string) {
+	ticker := time.NewTicker(refreshPollInterval)
+	defer ticker.Stop()
+
+	for {
+		progress := InstanceRefreshProgress{AutoScalingGroup: asgName, InstanceRefreshID: refreshID}
+
+		out, err := client.DescribeInstanceRefreshes(ctx, &autoscaling.DescribeInstanceRefreshesInput{
+			AutoScalingGroupName: aws.String(asgName),
+			InstanceRefreshIds:   []string{refreshID},
+		})
+		if err != nil {
+			slog.Warn("Unable to poll instance refresh", "refreshId", refreshID, "err", err)
+			progress.Error = err.Error()
+			a.emit("refresh:progress", progress)
+			return
+		}
+		if len(out.InstanceRefreshes) > 0 {
+			r := out.InstanceRefreshes[0]
+			progress.Status = string(r.Status)
+			progress.StatusReason = aws.ToString(r.StatusReason)
+			progress.PercentageComplete = int(aws.ToInt32(r.PercentageComplete))
+			progress.InstancesToUpdate = int(aws.ToInt32(r.InstancesToUpdate))
+		}
+		a.emit("refresh:progress", progress)
+		if refreshDoneStatuses[autoscalingtypes.InstanceRefreshStatus(progress.Status)] {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxRegionWorkers bounds how many regions are scanned at the same time
+const maxRegionWorkers = 4
+
+// allRegions requests a scan of every region enabled for the account
+const allRegions = "all"
+
+// ProcessingMultiRegion runs the scan in every given region and merges the results.
+// If regions is empty, the regions enabled for the account are scanned.
+// A failing region is reported in Errors instead of aborting the whole scan.
+func (a *App) ProcessingMultiRegion(profile string, regions []string, filter string) (*AWSResult, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(regions) == 0 {
+		regions = []string{allRegions}
+	}
+	regions, err = a.resolveRegions(a.ctx, cfg, regions)
+	if err != nil {
+		return nil, err
+	}
+	return a.scanRegions(a.ctx, cfg, regions, ProcessingRequest{Profile: profile, Filter: SSMFilter{NamePrefix: filter}})
+}
+
+// resolveRegions expands the "all" keyword into the enabled regions of the account
+func (a *App) resolveRegions(ctx context.Context, cfg aws.Config, regions []string) ([]string, error) {
+	for _, r := range regions {
+		if r == allRegions {
+			return a.enabledRegions(ctx, cfg)
+		}
+	}
+	return regions, nil
+}
+
+// scanRegions runs scanRegion for each region with bounded concurrency and merges the results
+func (a *App) scanRegions(ctx context.Context, cfg aws.Config, regions []string, req ProcessingRequest) (*AWSResult, error) {
+	results := make([]*AWSResult, len(regions))
+	var regionErrors []error
+	merged := &AWSResult{StaleAfterDays: req.staleAfterDays()}
+	var mu sync.Mutex
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxRegionWorkers)
+	for i, region := range regions {
+		g.Go(func() error {
+			regionCfg := cfg.Copy()
+			regionCfg.Region = region
+
+			res, err := a.scanRegion(ctx, regionCfg, req)
+			if err != nil {
+				mu.Lock()
+				regionErrors = append(regionErrors, fmt.Errorf("%s: %w", region, err))
+				merged.addError(region, "", err)
+				mu.Unlock()
+				return nil
+			}
+			results[i] = res
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(regionErrors) == len(regions) {
+		return nil, fmt.Errorf("scan failed in all %d regions: %w", len(regions), errors.Join(regionErrors...))
+	}
+
+	for _, res := range results {
+		if res == nil {
+			continue
+		}
+		merged.Parameters = append(merged.Parameters, res.Parameters...)
+		merged.Instances = append(merged.Instances, res.Instances...)
+		merged.Secrets = append(merged.Secrets, res.Secrets...)
+		merged.LambdaFunctions = append(merged.LambdaFunctions, res.LambdaFunctions...)
+		merged.Errors = append(merged.Errors, res.Errors...)
+		for service, resources := range res.Services {
+			if merged.Services == nil {
+				merged.Services = make(map[string][]ServiceResource)
+			}
+			merged.Services[service] = append(merged.Services[service], resources...)
+		}
+		merged.EstimatedMonthlyCostUSD += res.EstimatedMonthlyCostUSD
+		if res.AmiStorageReport != nil {
+			if merged.AmiStorageReport == nil {
+				merged.AmiStorageReport = &AmiStorageReport{}
+			}
+			merged.AmiStorageReport.merge(res.AmiStorageReport)
+		}
+		if res.Checks != nil {
+			if merged.Checks == nil {
+				merged.Checks = &CheckResults{}
+			}
+			merged.Checks.merge(res.Checks)
+		}
+	}
+	merged.Lifecycles = lifecycleBreakdown(merged.Instances)
+	sortScanErrors(merged.Errors)
+	return merged, nil
+}
+
+// sortScanErrors orders the errors by region, the failures of whole regions first
+func sortScanErrors(errs []ScanError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Region != errs[j].Region {
+			return errs[i].Region < errs[j].Region
+		}
+		return errs[i].Service < errs[j].Service
+	})
+}
+
+// RegionInfo describes a region for the frontend region picker
+type RegionInfo struct {
+	Name        string `json:"name"`
+	OptInStatus string `json:"optInStatus"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// ListRegions returns every region known to the account with its opt-in status.
+// Regions that still need an opt-in are returned with Enabled set to false.
+func (a *App) ListRegions(profile string) ([]RegionInfo, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := a.clientBackend().EC2(cfg).DescribeRegions(a.ctx, &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	regions := make([]RegionInfo, 0, len(out.Regions))
+	for _, r := range out.Regions {
+		status := aws.ToString(r.OptInStatus)
+		regions = append(regions, RegionInfo{
+			Name:        aws.ToString(r.RegionName),
+			OptInStatus: status,
+			Enabled:     status != "not-opted-in",
+		})
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Name < regions[j].Name })
+	return regions, nil
+}
+
+// enabledRegions lists the regions enabled for the account, sorted by name
+func (a *App) enabledRegions(ctx context.Context, cfg aws.Config) ([]string, error) {
+	out, err := a.clientBackend().EC2(cfg).DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	var regions []string
+	for _, r := range out.Regions {
+		if r.RegionName != nil {
+			regions = append(regions, *r.RegionName)
+		}
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
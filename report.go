@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Report formats accepted by ExportReport
+const (
+	ReportHTML     = "html"
+	ReportMarkdown = "markdown"
+)
+
+//go:embed templates/report.html.tmpl templates/report.md.tmpl
+var reportTemplates embed.FS
+
+// reportData is what the report templates render
+type reportData struct {
+	GeneratedAt    string
+	Identity       *CallerIdentity
+	AssumedRole    string
+	StaleAfterDays int
+	Summary        reportSummary
+	// Charts are the breakdowns drawn as bar charts in the HTML report
+	Charts   []reportChart
+	AMIs     []AMIGroup
+	Accounts []reportAccount
+	// Errors lists the failed parts of the scan, sorted by region
+	Errors []ScanError
+}
+
+type reportSummary struct {
+	Instances    int
+	Stale        int
+	NonCompliant int
+	IMDSv1       int
+	AMIs         int
+	Parameters   int
+	MonthlyCost  float64
+}
+
+// reportChart is a labelled breakdown; Max scales the bars
+type reportChart struct {
+	Title string
+	Bars  []reportBar
+	Max   int
+}
+
+type reportBar struct {
+	Label string
+	Value int
+}
+
+// reportAccount holds the instances of one account, with its own stale count
+type reportAccount struct {
+	AccountID string
+	Instances []EC2Instance
+	Stale     int
+}
+
+// ExportResultReport asks where to save and writes the report with ExportReport.
+// It returns the path written, or "" if the user cancelled the dialog.
+func (a *App) ExportResultReport(result AWSResult, format string) (string, error) {
+	filename, filter := "scan-report.html", runtime.FileFilter{DisplayName: "HTML", Pattern: "*.html"}
+	if format == ReportMarkdown {
+		filename, filter = "scan-report.md", runtime.FileFilter{DisplayName: "Markdown", Pattern: "*.md"}
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export scan report",
+		DefaultFilename: filename,
+		Filters:         []runtime.FileFilter{filter},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	return path, a.ExportReport(result, format, path)
+}
+
+// ExportReport renders the result as a standalone HTML or Markdown report: a summary,
+// breakdowns by state, region, lifecycle and AMI age, the AMIs in use and a table of
+// instances per account
+func (a *App) ExportReport(result AWSResult, format, path string) error {
+	content, err := renderReport(result, format, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// renderReport executes the template of the format on the result
+func renderReport(result AWSResult, format string, now time.Time) ([]byte, error) {
+	data := newReportData(result, now)
+	var buf bytes.Buffer
+
+	switch format {
+	case ReportHTML:
+		tmpl, err := htmltemplate.New("report.html.tmpl").Funcs(htmltemplate.FuncMap{
+			"percent": barPercent,
+		}).ParseFS(reportTemplates, "templates/report.html.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse html report template: %w", err)
+		}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render html report: %w", err)
+		}
+	case ReportMarkdown:
+		tmpl, err := template.New("report.md.tmpl").Funcs(template.FuncMap{
+			"cell": markdownCell,
+		}).ParseFS(reportTemplates, "templates/report.md.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse markdown report template: %w", err)
+		}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render markdown report: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown report format %q: must be %s or %s", format, ReportHTML, ReportMarkdown)
+	}
+	return buf.Bytes(), nil
+}
+
+// newReportData computes the summary, breakdowns and per-account tables of a result
+func newReportData(result AWSResult, now time.Time) reportData {
+	data := reportData{
+		GeneratedAt:    now.UTC().Format(time.RFC3339),
+		Identity:       result.Identity,
+		AssumedRole:    result.AssumedRole,
+		StaleAfterDays: result.StaleAfterDays,
+		AMIs:           knownAMIGroups(&result),
+		Summary: reportSummary{
+			Instances:   len(result.Instances),
+			Parameters:  len(result.Parameters),
+			MonthlyCost: result.EstimatedMonthlyCostUSD,
+		},
+	}
+	data.Summary.AMIs = len(data.AMIs)
+
+	states := map[string]int{}
+	regions := map[string]int{}
+	lifecycles := map[string]int{}
+	ages := map[string]int{}
+	accounts := map[string]*reportAccount{}
+	for _, inst := range result.Instances {
+		if inst.AMIStale {
+			data.Summary.Stale++
+		}
+		if inst.NonCompliant {
+			data.Summary.NonCompliant++
+		}
+		if inst.IMDSv1Allowed {
+			data.Summary.IMDSv1++
+		}
+		states[orUnknown(inst.State)]++
+		regions[orUnknown(inst.Region)]++
+		lifecycles[orUnknown(inst.Lifecycle)]++
+		ages[amiAgeBucket(inst)]++
+
+		id := inst.AccountID
+		if id == "" && result.Identity != nil {
+			id = result.Identity.AccountID
+		}
+		id = orUnknown(id)
+		acct, ok := accounts[id]
+		if !ok {
+			acct = &reportAccount{AccountID: id}
+			accounts[id] = acct
+		}
+		acct.Instances = append(acct.Instances, inst)
+		if inst.AMIStale {
+			acct.Stale++
+		}
+	}
+
+	data.Charts = []reportChart{
+		newReportChart("Instances by state", states),
+		newReportChart("Instances by region", regions),
+		newReportChart("Instances by lifecycle", lifecycles),
+		newReportChart("Instances by AMI age", ages),
+	}
+
+	ids := make([]string, 0, len(accounts))
+	for id := range accounts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		data.Accounts = append(data.Accounts, *accounts[id])
+	}
+
+	data.Errors = append([]ScanError(nil), result.Errors...)
+	sortScanErrors(data.Errors)
+	return data
+}
+
+// newReportChart turns counts into bars, largest first
+func newReportChart(title string, counts map[string]int) reportChart {
+	chart := reportChart{Title: title}
+	for label, n := range counts {
+		chart.Bars = append(chart.Bars, reportBar{Label: label, Value: n})
+		chart.Max = max(chart.Max, n)
+	}
+	sort.Slice(chart.Bars, func(i, j int) bool {
+		if chart.Bars[i].Value != chart.Bars[j].Value {
+			return chart.Bars[i].Value > chart.Bars[j].Value
+		}
+		return chart.Bars[i].Label < chart.Bars[j].Label
+	})
+	return chart
+}
+
+// amiAgeBucket labels the age range of the instance's AMI
+func amiAgeBucket(inst EC2Instance) string {
+	switch {
+	case inst.AMICreationDate == "":
+		return unknownAMI
+	case inst.AMIAgeDays < 30:
+		return "< 30 days"
+	case inst.AMIAgeDays < 90:
+		return "30-89 days"
+	case inst.AMIAgeDays < 180:
+		return "90-179 days"
+	case inst.AMIAgeDays < 365:
+		return "180-364 days"
+	default:
+		return "365+ days"
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// barPercent is the width of a bar relative to the largest one
+func barPercent(value, maxValue int) int {
+	if maxValue == 0 {
+		return 0
+	}
+	return value * 100 / maxValue
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
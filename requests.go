@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ProcessingRequest describes one scan started from the frontend.
+// RequestID lets the frontend cancel it with CancelProcessing.
+// Regions may hold "all" to fan out to every enabled region.
+type ProcessingRequest struct {
+	RequestID string    `json:"requestId"`
+	Profile   string    `json:"profile"`
+	Regions   []string  `json:"regions"`
+	Filter    SSMFilter `json:"filter"`
+	// Refresh drops the cached config of Profile, re-reading the config files and
+	// re-authenticating; like ForceRefresh, it skips the result cache
+	Refresh bool `json:"refresh"`
+	// ForceRefresh rescans instead of returning the cached result of the same scan
+	ForceRefresh bool `json:"forceRefresh"`
+	// InstanceFilter restricts the EC2 scan, e.g. to Environment=prod
+	InstanceFilter EC2Filter `json:"instanceFilter"`
+	// StaleAfterDays marks AMIs older than this as stale (default 90)
+	StaleAfterDays int `json:"staleAfterDays"`
+	// CompareLatest looks up the latest Amazon Linux / Ubuntu / Windows AMIs
+	CompareLatest bool `json:"compareLatest"`
+	// EstimateCost attaches the on-demand price of each running instance
+	EstimateCost bool `json:"estimateCost"`
+	// Checks enables the optional checks, returned in AWSResult.Checks
+	Checks CheckOptions `json:"checks"`
+	// Secrets also lists the Secrets Manager secrets matching Filter
+	Secrets bool `json:"secrets"`
+	// Lambda lists the Lambda functions and flags deprecated runtimes
+	Lambda bool `json:"lambda"`
+	// StackMapping looks up the CloudFormation stack of instances without stack tags
+	StackMapping bool `json:"stackMapping"`
+	// Services lists the other services to scan, e.g. "rds"; see serviceScanners
+	Services []string `json:"services"`
+	// ApprovedRegistries are the container registries allowed by the ECS audit,
+	// e.g. 123456789012.dkr.ecr.eu-west-1.amazonaws.com; any registry when empty
+	ApprovedRegistries []string `json:"approvedRegistries"`
+	// StorageReport estimates the snapshot storage cost of the AMIs owned by the account
+	StorageReport bool `json:"storageReport"`
+	// GoldenAMIs and GoldenAMIParameter form the approved AMI allow-list;
+	// instances on any other AMI are marked non-compliant
+	GoldenAMIs         []string `json:"goldenAmis"`
+	GoldenAMIParameter string   `json:"goldenAmiParameter"`
+}
+
+// defaultStaleAfterDays is the AMI age considered stale when none is requested
+const defaultStaleAfterDays = 90
+
+func (r ProcessingRequest) staleAfterDays() int {
+	if r.StaleAfterDays > 0 {
+		return r.StaleAfterDays
+	}
+	return defaultStaleAfterDays
+}
+
+// ProcessRequest runs a scan that can be cancelled through its request ID.
+// Without regions the profile's default region is scanned. The result of the same
+// scan is returned from the local cache while it is fresh, unless ForceRefresh is set.
+// Favorite instances and parameters come first, and the policies are evaluated.
+func (a *App) ProcessRequest(req ProcessingRequest) (*AWSResult, error) {
+	ctx, done, err := a.beginRequest(req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	ctx = withRequestID(ctx, req.RequestID)
+	start := time.Now()
+	slog.Debug("Scan started", "requestId", req.RequestID, "profile", req.Profile, "regions", req.Regions)
+	result, err := a.processRequest(ctx, req)
+	if err == nil {
+		a.pinFavorites(result)
+		if err := a.applyPolicy(ctx, result); err != nil {
+			result.addError("", regoService, err)
+		}
+		a.reportProgress(ctx, ScanProgress{Phase: "done", Items: len(result.Parameters) + len(result.Instances)})
+		slog.Debug("Scan done", "requestId", req.RequestID, "duration", time.Since(start),
+			"instances", len(result.Instances), "parameters", len(result.Parameters), "cached", result.Cached)
+	} else {
+		slog.Debug("Scan failed", "requestId", req.RequestID, "duration", time.Since(start), "err", err)
+	}
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return nil, fmt.Errorf("request %q cancelled", req.RequestID)
+	}
+	return result, err
+}
+
+func (a *App) processRequest(ctx context.Context, req ProcessingRequest) (*AWSResult, error) {
+	if req.Refresh {
+		a.InvalidateProfile(req.Profile)
+	} else if !req.ForceRefresh {
+		if cached := a.cachedResult(req); cached != nil {
+			return cached, nil
+		}
+	}
+	cfg, identity, err := a.authenticateIdentity(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.scanAccount(ctx, cfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Identity = a.callerIdentity(ctx, cfg, identity)
+	result.AssumedRole = assumedRoleARN(aws.ToString(identity.Arn))
+	result.RoleChain = a.roleChainARNs(req.Profile)
+	result.ScannedAt = time.Now().UTC().Format(time.RFC3339)
+	a.storeResult(req, result)
+	a.recordScan(req, result)
+	return result, nil
+}
+
+// scanAccount scans the regions of req with the credentials of cfg and applies the golden AMI check
+func (a *App) scanAccount(ctx context.Context, cfg aws.Config, req ProcessingRequest) (*AWSResult, error) {
+	var result *AWSResult
+	var err error
+	if len(req.Regions) == 0 {
+		result, err = a.scanRegion(ctx, cfg, req)
+	} else {
+		var regions []string
+		regions, err = a.resolveRegions(ctx, cfg, req.Regions)
+		if err != nil {
+			return nil, err
+		}
+		result, err = a.scanRegions(ctx, cfg, regions, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GoldenAMIs) > 0 || req.GoldenAMIParameter != "" {
+		golden, err := a.goldenAMIs(ctx, cfg, req)
+		if err != nil {
+			return nil, err
+		}
+		applyGoldenAMIs(result, golden)
+	}
+	return result, nil
+}
+
+// CancelProcessing aborts the running request with the given ID
+func (a *App) CancelProcessing(requestID string) error {
+	a.mu.Lock()
+	cancel, ok := a.requests[requestID]
+	a.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no running request with id %q", requestID)
+	}
+	cancel()
+	return nil
+}
+
+// beginRequest registers a cancellable context for requestID.
+// The returned func must be called once the request is over.
+func (a *App) beginRequest(requestID string) (context.Context, func(), error) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	if requestID == "" {
+		return ctx, cancel, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, exists := a.requests[requestID]; exists {
+		cancel()
+		return nil, nil, fmt.Errorf("request %q is already running", requestID)
+	}
+	a.requests[requestID] = cancel
+
+	return ctx, func() {
+		a.mu.Lock()
+		delete(a.requests, requestID)
+		a.mu.Unlock()
+		cancel()
+	}, nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// resultsBucket holds the last scan per profile, regions and options
+var resultsBucket = []byte("results")
+
+// defaultResultCacheTTL is how long a scan is served from the cache when no TTL is set
+const defaultResultCacheTTL = time.Hour
+
+// cachedScan is a scan as stored in the results bucket
+type cachedScan struct {
+	Profile   string     `json:"profile"`
+	ScannedAt time.Time  `json:"scannedAt"`
+	Result    *AWSResult `json:"result"`
+}
+
+// setResultCacheTTL sets how long scans are served from the cache, from the
+// minutes of the settings: 0 keeps the default, a negative value disables the cache
+func (a *App) setResultCacheTTL(minutes int) {
+	ttl := defaultResultCacheTTL
+	switch {
+	case minutes < 0:
+		ttl = 0
+	case minutes > 0:
+		ttl = time.Duration(minutes) * time.Minute
+	}
+	a.mu.Lock()
+	a.resultCacheTTL = ttl
+	a.mu.Unlock()
+}
+
+func (a *App) resultTTL() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.resultCacheTTL
+}
+
+// resultCacheKey identifies the scans of the same profile and regions with the same
+// options. The profile comes first so LastCachedResult can scan its entries by prefix.
+func resultCacheKey(req ProcessingRequest) []byte {
+	regions := append([]string{}, req.Regions...)
+	sort.Strings(regions)
+
+	// Fields that don't change what is scanned are left out of the hash
+	opts := req
+	opts.RequestID, opts.Profile, opts.Regions = "", "", nil
+	opts.Refresh, opts.ForceRefresh = false, false
+	data, _ := json.Marshal(opts)
+	sum := sha256.Sum256(data)
+
+	return []byte(req.Profile + "\x00" + strings.Join(regions, ",") + "\x00" + hex.EncodeToString(sum[:8]))
+}
+
+// cachedResult returns the cached scan of req, nil when there is none or it expired
+func (a *App) cachedResult(req ProcessingRequest) *AWSResult {
+	ttl := a.resultTTL()
+	if ttl == 0 {
+		return nil
+	}
+	db, err := a.db()
+	if err != nil {
+		slog.Warn("Unable to read the result cache", "err", err)
+		return nil
+	}
+
+	var scan cachedScan
+	err = db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(resultsBucket).Get(resultCacheKey(req))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &scan)
+	})
+	if err != nil {
+		slog.Warn("Unable to read the result cache", "err", err)
+		return nil
+	}
+	if scan.Result == nil || time.Since(scan.ScannedAt) > ttl {
+		return nil
+	}
+	scan.Result.Cached = true
+	return scan.Result
+}
+
+// storeResult caches the scan of req, dropping the expired entries on the way.
+// Partial scans aren't cached so that the next one retries the failed parts.
+func (a *App) storeResult(req ProcessingRequest, result *AWSResult) {
+	ttl := a.resultTTL()
+	if ttl == 0 || len(result.Errors) > 0 {
+		return
+	}
+	db, err := a.db()
+	if err != nil {
+		slog.Warn("Unable to write the result cache", "err", err)
+		return
+	}
+
+	data, err := json.Marshal(cachedScan{Profile: req.Profile, ScannedAt: time.Now(), Result: result})
+	if err != nil {
+		slog.Warn("Unable to encode the scan for the result cache", "err", err)
+		return
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(resultsBucket)
+		var expired [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			var scan cachedScan
+			if json.Unmarshal(v, &scan) != nil || time.Since(scan.ScannedAt) > ttl {
+				expired = append(expired, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return bucket.Put(resultCacheKey(req), data)
+	})
+	if err != nil {
+		slog.Warn("Unable to write the result cache", "err", err)
+	}
+}
+
+// LastCachedResult returns the most recent unexpired scan of the profile, whatever
+// its regions and options, so the last results show instantly on startup.
+// It returns nil when nothing is cached.
+func (a *App) LastCachedResult(profile string) (*AWSResult, error) {
+	ttl := a.resultTTL()
+	if ttl == 0 {
+		return nil, nil
+	}
+	db, err := a.db()
+	if err != nil {
+		return nil, err
+	}
+
+	var last *cachedScan
+	prefix := []byte(profile + "\x00")
+	err = db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(resultsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var scan cachedScan
+			if err := json.Unmarshal(v, &scan); err != nil {
+				continue
+			}
+			if scan.Result != nil && time.Since(scan.ScannedAt) <= ttl && (last == nil || scan.ScannedAt.After(last.ScannedAt)) {
+				last = &scan
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the result cache: %w", err)
+	}
+	if last == nil {
+		return nil, nil
+	}
+	last.Result.Cached = true
+	return last.Result, nil
+}
+
+// ClearResultCache drops every cached scan
+func (a *App) ClearResultCache() error {
+	db, err := a.db()
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(resultsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(resultsBucket)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear the result cache: %w", err)
+	}
+	return nil
+}
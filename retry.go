@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Retry modes: standard retries with exponential backoff, adaptive also slows
+// the client down while the account is throttled
+const (
+	RetryModeStandard = "standard"
+	RetryModeAdaptive = "adaptive"
+)
+
+// SetRetryConfig sets how throttled or failed API calls are retried.
+// Zero values keep the SDK defaults (3 attempts, 20s max backoff).
+// The configs built with another retryer are dropped.
+func (a *App) SetRetryConfig(maxAttempts int, maxBackoff time.Duration) error {
+	if maxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative, got %d", maxAttempts)
+	}
+	if maxBackoff < 0 {
+		return fmt.Errorf("max backoff must not be negative, got %s", maxBackoff)
+	}
+
+	a.mu.Lock()
+	changed := a.retryMaxAttempts != maxAttempts || a.retryMaxBackoff != maxBackoff
+	a.retryMaxAttempts = maxAttempts
+	a.retryMaxBackoff = maxBackoff
+	a.mu.Unlock()
+	if changed {
+		a.invalidateAllProfiles()
+	}
+	return nil
+}
+
+// retrySettings are the retry and timeout settings parsed from the prefs
+type retrySettings struct {
+	mode        string
+	maxAttempts int
+	maxBackoff  time.Duration
+	timeout     time.Duration
+	timeouts    map[string]time.Duration
+}
+
+// setRetryPrefs applies the retry mode, attempts, backoff and operation timeouts
+// of the settings
+func (a *App) setRetryPrefs(prefs UserPrefs) error {
+	settings, err := parseRetryPrefs(prefs)
+	if err != nil {
+		return err
+	}
+	a.applyRetrySettings(settings)
+	return nil
+}
+
+// parseRetryPrefs validates the retry and timeout settings of prefs
+func parseRetryPrefs(prefs UserPrefs) (retrySettings, error) {
+	mode := prefs.RetryMode
+	if mode == "" {
+		mode = RetryModeStandard
+	}
+	if mode != RetryModeStandard && mode != RetryModeAdaptive {
+		return retrySettings{}, fmt.Errorf("unknown retry mode %q: must be %s or %s", prefs.RetryMode, RetryModeStandard, RetryModeAdaptive)
+	}
+	if prefs.RetryMaxAttempts < 0 {
+		return retrySettings{}, fmt.Errorf("max attempts must not be negative, got %d", prefs.RetryMaxAttempts)
+	}
+	if prefs.RetryMaxBackoffSeconds < 0 {
+		return retrySettings{}, fmt.Errorf("max backoff must not be negative, got %ds", prefs.RetryMaxBackoffSeconds)
+	}
+	if prefs.OperationTimeoutSeconds < 0 {
+		return retrySettings{}, fmt.Errorf("operation timeout must not be negative, got %ds", prefs.OperationTimeoutSeconds)
+	}
+	timeouts := make(map[string]time.Duration, len(prefs.OperationTimeouts))
+	for op, seconds := range prefs.OperationTimeouts {
+		if seconds < 0 {
+			return retrySettings{}, fmt.Errorf("timeout of %s must not be negative, got %ds", op, seconds)
+		}
+		timeouts[op] = time.Duration(seconds) * time.Second
+	}
+
+	return retrySettings{
+		mode:        mode,
+		maxAttempts: prefs.RetryMaxAttempts,
+		maxBackoff:  time.Duration(prefs.RetryMaxBackoffSeconds) * time.Second,
+		timeout:     time.Duration(prefs.OperationTimeoutSeconds) * time.Second,
+		timeouts:    timeouts,
+	}, nil
+}
+
+// applyRetrySettings switches to the retry and timeout settings. The configs built
+// with another retryer are dropped.
+func (a *App) applyRetrySettings(s retrySettings) {
+	a.mu.Lock()
+	changed := a.retryMode != s.mode || a.retryMaxAttempts != s.maxAttempts || a.retryMaxBackoff != s.maxBackoff
+	a.retryMode = s.mode
+	a.retryMaxAttempts = s.maxAttempts
+	a.retryMaxBackoff = s.maxBackoff
+	a.callTimeout = s.timeout
+	a.callTimeouts = s.timeouts
+	a.mu.Unlock()
+	if changed {
+		a.invalidateAllProfiles()
+	}
+}
+
+// retryLoadOption builds the retryer shared by every client created from the config.
+// The SDK sleeps between attempts with the request context, so a cancellation
+// during backoff returns right away instead of waiting the window out.
+func (a *App) retryLoadOption() config.LoadOptionsFunc {
+	a.mu.Lock()
+	mode, maxAttempts, maxBackoff := a.retryMode, a.retryMaxAttempts, a.retryMaxBackoff
+	a.mu.Unlock()
+
+	standard := func(o *retry.StandardOptions) {
+		if maxAttempts > 0 {
+			o.MaxAttempts = maxAttempts
+		}
+		if maxBackoff > 0 {
+			o.MaxBackoff = maxBackoff
+			o.Backoff = retry.NewExponentialJitterBackoff(maxBackoff)
+		}
+	}
+	return config.WithRetryer(func() aws.Retryer {
+		if mode == RetryModeAdaptive {
+			// The adaptive token bucket is per client: each client created from
+			// the config backs off on its own throttling
+			return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+				o.StandardOptions = append(o.StandardOptions, standard)
+			})
+		}
+		return retry.NewStandard(standard)
+	})
+}
+
+// timeoutFor returns the timeout of an operation: its own, else the default one.
+// Zero means no timeout.
+func (a *App) timeoutFor(operation string) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if timeout, ok := a.callTimeouts[operation]; ok {
+		return timeout
+	}
+	return a.callTimeout
+}
+
+// timeoutLoadOption bounds every API call, retries and backoff included, by the
+// timeout of its operation. The timeouts are read on each call, so changing
+// them needs no new config.
+func (a *App) timeoutLoadOption() config.LoadOptionsFunc {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OperationTimeout", a.applyOperationTimeout), middleware.After)
+		},
+	})
+}
+
+// applyOperationTimeout runs the call with the timeout of its operation. The
+// timeout is canceled once the call returns, which is fine as none of the
+// operations used stream their response.
+func (a *App) applyOperationTimeout(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	timeout := a.timeoutFor(awsmiddleware.GetOperationName(ctx))
+	if timeout <= 0 {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	out, metadata, err := next.HandleInitialize(callCtx, in)
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return out, metadata, err
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxRoleChainHops bounds role_arn/source_profile chains, catching runaway configs
+const maxRoleChainHops = 10
+
+// roleHop is one role assumed while resolving a profile's credentials
+type roleHop struct {
+	profile   string
+	roleARN   string
+	mfaSerial string
+}
+
+// roleChain follows source_profile from profile and returns the roles to assume,
+// in the order they are assumed (the profile's own role last). The SDK does the
+// assuming (external_id included); this only validates the chain so a broken
+// config gets a clear error instead of an opaque credential failure.
+func (a *App) roleChain(profile string) ([]roleHop, error) {
+	var hops []roleHop
+	seen := map[string]bool{}
+	for p := profile; ; {
+		role := a.getProfileValue(p, "role_arn")
+		if role == "" {
+			break
+		}
+		if len(hops) == maxRoleChainHops {
+			return nil, fmt.Errorf("profile %q: role chain longer than %d hops", profile, maxRoleChainHops)
+		}
+		seen[p] = true
+		hops = append(hops, roleHop{
+			profile:   p,
+			roleARN:   role,
+			mfaSerial: a.getProfileValue(p, "mfa_serial"),
+		})
+
+		source := a.getProfileValue(p, "source_profile")
+		if source == "" {
+			if a.getProfileValue(p, "credential_source") == "" {
+				return nil, fmt.Errorf("profile %q has role_arn but neither source_profile nor credential_source", p)
+			}
+			break
+		}
+		// A profile may source its own static keys to assume its role
+		if source == p {
+			break
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("profile %q: source_profile loop through %q", profile, source)
+		}
+		p = source
+	}
+
+	for i, j := 0, len(hops)-1; i < j; i, j = i+1, j-1 {
+		hops[i], hops[j] = hops[j], hops[i]
+	}
+	return hops, nil
+}
+
+// roleChainARNs returns the role ARNs of a profile's chain in assumption order
+func (a *App) roleChainARNs(profile string) []string {
+	hops, err := a.roleChain(profile)
+	if err != nil {
+		return nil
+	}
+	arns := make([]string, len(hops))
+	for i, h := range hops {
+		arns[i] = h.roleARN
+	}
+	return arns
+}
+
+// assumedRoleARN converts an STS assumed-role ARN
+// (arn:aws:sts::123456789012:assumed-role/Name/session) to the role ARN,
+// or returns "" for users and other principals
+func assumedRoleARN(callerARN string) string {
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return ""
+	}
+	resource := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], resource[0])
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// s3Service is the service key of the bucket audit
+const s3Service = "s3"
+
+// noPublicAccessBlock is returned by GetPublicAccessBlock for buckets without a configuration
+const noPublicAccessBlock = "NoSuchPublicAccessBlockConfiguration"
+
+// scanS3 lists the buckets of the region of cfg with their Block Public Access and
+// default encryption settings. Buckets not blocking all public access are flagged;
+// an account-level block may still apply, it isn't checked.
+func (a *App) scanS3(ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error) {
+	client := s3.NewFromConfig(cfg)
+	resources := []ServiceResource{}
+
+	// Buckets are global; only those of the scanned region are listed so a
+	// multi-region scan reports each bucket once
+	pager := s3.NewListBucketsPaginator(client, &s3.ListBucketsInput{BucketRegion: aws.String(cfg.Region)})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list buckets: %w", err)
+		}
+		for _, b := range page.Buckets {
+			resources = append(resources, bucketAudit(ctx, client, aws.ToString(b.Name), cfg.Region))
+		}
+	}
+	return resources, nil
+}
+
+// bucketAudit reads the public access block and encryption of a bucket.
+// A denied read is reported in the finding rather than failing the scan.
+func bucketAudit(ctx context.Context, client *s3.Client, bucket, region string) ServiceResource {
+	r := ServiceResource{
+		Service: s3Service,
+		Type:    "bucket",
+		ID:      bucket,
+		ARN:     "arn:aws:s3:::" + bucket,
+		Region:  region,
+		Details: map[string]string{},
+	}
+	var findings []string
+
+	pab, err := client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(bucket)})
+	var apiErr smithy.APIError
+	switch {
+	case err == nil && pab.PublicAccessBlockConfiguration != nil:
+		c := pab.PublicAccessBlockConfiguration
+		settings := map[string]*bool{
+			"blockPublicAcls":       c.BlockPublicAcls,
+			"ignorePublicAcls":      c.IgnorePublicAcls,
+			"blockPublicPolicy":     c.BlockPublicPolicy,
+			"restrictPublicBuckets": c.RestrictPublicBuckets,
+		}
+		var off []string
+		for name, v := range settings {
+			r.Details[name] = strconv.FormatBool(aws.ToBool(v))
+			if !aws.ToBool(v) {
+				off = append(off, name)
+			}
+		}
+		if len(off) > 0 {
+			r.Status = "partially blocked"
+			findings = append(findings, fmt.Sprintf("%d public access block setting(s) off", len(off)))
+		} else {
+			r.Status = "public access blocked"
+		}
+	case err == nil, errors.As(err, &apiErr) && apiErr.ErrorCode() == noPublicAccessBlock:
+		r.Status = "not blocked"
+		findings = append(findings, "no public access block")
+	default:
+		r.Status = "unknown"
+		findings = append(findings, "public access block: "+err.Error())
+	}
+
+	enc, err := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(bucket)})
+	switch {
+	case err != nil:
+		findings = append(findings, "encryption: "+err.Error())
+	case enc.ServerSideEncryptionConfiguration == nil || len(enc.ServerSideEncryptionConfiguration.Rules) == 0:
+		r.Version = "none"
+		findings = append(findings, "no default encryption")
+	default:
+		if def := enc.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault; def != nil {
+			r.Version = string(def.SSEAlgorithm)
+			if def.KMSMasterKeyID != nil {
+				r.Details["kmsKeyId"] = aws.ToString(def.KMSMasterKeyID)
+			}
+		}
+	}
+
+	r.Flagged = len(findings) > 0
+	r.Finding = strings.Join(findings, "; ")
+	return r
+}
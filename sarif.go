@@ -0,0 +1,239 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://github.com/apavanello/goCheckAmi"
+)
+
+// sarifRule is a finding type of the SARIF export
+type sarifRule struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	Help             sarifMessage      `json:"help"`
+	Default          sarifRuleDefaults `json:"defaultConfiguration"`
+}
+
+type sarifRuleDefaults struct {
+	Level string `json:"level"`
+}
+
+// sarifRules are the findings ExportSARIF reports; levels are error, warning or note
+var sarifRules = []sarifRule{
+	newSARIFRule("AMI001", "StaleAMI", "warning", "Instance runs a stale AMI",
+		"The AMI is older than the staleness threshold of the scan. Rebuild the instance from a recent AMI."),
+	newSARIFRule("AMI002", "DeprecatedAMI", "warning", "Instance runs a deprecated AMI",
+		"The AMI is past its deprecation date. Move the instance to a supported AMI."),
+	newSARIFRule("AMI003", "DeregisteredAMI", "error", "Instance runs a deregistered AMI",
+		"The AMI no longer exists, so the instance can't be relaunched as is. Move it to a registered AMI."),
+	newSARIFRule("AMI004", "NonGoldenAMI", "error", "Instance doesn't run a golden AMI",
+		"The AMI is not on the approved golden AMI list. Relaunch the instance from a golden AMI."),
+	newSARIFRule("SEC001", "IMDSv1Allowed", "warning", "Instance metadata allows IMDSv1",
+		"The metadata endpoint doesn't require session tokens. Set HttpTokens to required."),
+	newSARIFRule("SEC002", "InstanceRoleFinding", "warning", "Instance profile needs attention",
+		"The instance has no IAM role or a role with broad policies. Attach a least-privilege role."),
+	newSARIFRule("SEC003", "OpenSensitivePort", "error", "Sensitive port open to the internet",
+		"A security group of the instance opens a sensitive port to 0.0.0.0/0 or ::/0. Restrict the source range."),
+	newSARIFRule("SEC004", "UnencryptedVolume", "warning", "EBS volume is not encrypted",
+		"An attached EBS volume is not encrypted. Replace it with an encrypted copy."),
+}
+
+func newSARIFRule(id, name, level, short, help string) sarifRule {
+	return sarifRule{ID: id, Name: name, ShortDescription: sarifMessage{Text: short}, Help: sarifMessage{Text: help}, Default: sarifRuleDefaults{Level: level}}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+// sarifLocation points at the AWS resource. Code-scanning dashboards require a
+// physical location, so the resource is also given as a pseudo path.
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// ExportResultSARIF asks where to save and writes the findings with ExportSARIF.
+// It returns the path written, or "" if the user cancelled the dialog.
+func (a *App) ExportResultSARIF(result AWSResult) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export findings",
+		DefaultFilename: "scan.sarif",
+		Filters:         []runtime.FileFilter{{DisplayName: "SARIF", Pattern: "*.sarif;*.sarif.json"}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	return path, a.ExportSARIF(result, path)
+}
+
+// ExportSARIF writes the AMI and security findings of the result as a SARIF 2.1.0
+// log, one result per finding, located at the ARN of the instance or volume
+func (a *App) ExportSARIF(result AWSResult, path string) error {
+	data, err := json.MarshalIndent(newSARIFLog(result), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sarif: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// newSARIFLog collects the findings of the result
+func newSARIFLog(result AWSResult) sarifLog {
+	partition, account := "aws", ""
+	if result.Identity != nil {
+		partition, account = arnPartition(result.Identity.ARN), result.Identity.AccountID
+	}
+
+	results := []sarifResult{}
+	// key tells apart the findings of a rule on the same resource; with the rule and
+	// the ARN it fingerprints the finding across scans, unlike the message
+	add := func(ruleID, arn, name, key, message string) {
+		index := -1
+		for i, r := range sarifRules {
+			if r.ID == ruleID {
+				index = i
+			}
+		}
+		sum := sha256.Sum256([]byte(ruleID + "|" + arn + "|" + key))
+		results = append(results, sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Level:     sarifRules[index].Default.Level,
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifResourceURI(arn)}},
+				LogicalLocations: []sarifLogicalLocation{{Name: name, FullyQualifiedName: arn, Kind: "resource"}},
+			}},
+			PartialFingerprints: map[string]string{"resourceFinding/v1": hex.EncodeToString(sum[:])},
+		})
+	}
+
+	for _, inst := range result.Instances {
+		acct := inst.AccountID
+		if acct == "" {
+			acct = account
+		}
+		arn := fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", partition, inst.Region, acct, inst.InstanceID)
+		label := inst.InstanceID
+		if inst.Name != "" {
+			label += " (" + inst.Name + ")"
+		}
+
+		switch inst.AMIStatus {
+		case AMIStatusDeregistered:
+			add("AMI003", arn, inst.InstanceID, inst.AMI, fmt.Sprintf("%s runs %s, which is deregistered", label, inst.AMI))
+		case AMIStatusDeprecated:
+			add("AMI002", arn, inst.InstanceID, inst.AMI, fmt.Sprintf("%s runs %s, which is deprecated", label, inst.AMI))
+		}
+		if inst.AMIStale {
+			msg := fmt.Sprintf("%s runs %s, created %d days ago (threshold %d days)", label, inst.AMI, inst.AMIAgeDays, result.StaleAfterDays)
+			if inst.RecommendedAMI != "" {
+				msg += "; the latest AMI of its family is " + inst.RecommendedAMI
+			}
+			add("AMI001", arn, inst.InstanceID, inst.AMI, msg)
+		}
+		if inst.NonCompliant {
+			add("AMI004", arn, inst.InstanceID, inst.AMI, fmt.Sprintf("%s runs %s, which is not a golden AMI", label, inst.AMI))
+		}
+		if inst.IMDSv1Allowed {
+			add("SEC001", arn, inst.InstanceID, "", fmt.Sprintf("%s allows IMDSv1 (HttpTokens %s)", label, inst.IMDSHttpTokens))
+		}
+		if inst.IAMFinding != "" {
+			add("SEC002", arn, inst.InstanceID, "", fmt.Sprintf("%s: %s", label, inst.IAMFinding))
+		}
+		for _, e := range inst.Exposures {
+			add("SEC003", arn, inst.InstanceID, fmt.Sprintf("%s/%d/%s", e.GroupID, e.Port, e.CIDR), fmt.Sprintf("%s: security group %s opens port %d (%s) to %s", label, e.GroupID, e.Port, e.Service, e.CIDR))
+		}
+	}
+
+	if result.Checks != nil {
+		for _, v := range result.Checks.Volumes {
+			if v.Volume.Encrypted {
+				continue
+			}
+			arn := fmt.Sprintf("arn:%s:ec2:%s:%s:volume/%s", partition, v.Region, account, v.Volume.VolumeID)
+			add("SEC004", arn, v.Volume.VolumeID, "", fmt.Sprintf("Volume %s (%s) of %s is not encrypted", v.Volume.VolumeID, v.Volume.DeviceName, v.InstanceID))
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "goCheckAmi", InformationURI: sarifToolURI, Rules: sarifRules}},
+			Results: results,
+		}},
+	}
+}
+
+// sarifResourceURI turns an ARN into a relative path, as dashboards expect file locations:
+// arn:aws:ec2:us-east-1:123:instance/i-1 becomes aws/123/us-east-1/ec2/instance/i-1
+func sarifResourceURI(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return arn
+	}
+	return strings.Join([]string{parts[1], orUnknown(parts[4]), orUnknown(parts[3]), parts[2], parts[5]}, "/")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// scheduledRequestPrefix marks the request IDs of scheduled scans, so a run
+// still going when the next one is due is skipped instead of doubled
+const scheduledRequestPrefix = "schedule:"
+
+// ScheduleAlert is emitted as "schedule:alert" when a scheduled scan finds new
+// stale AMIs or parameter changes since the previous scan of the same search
+type ScheduleAlert struct {
+	Preset    string `json:"preset"`
+	Profile   string `json:"profile"`
+	ScannedAt string `json:"scannedAt"`
+	// NewStaleAMIs are the AMIs that became stale, or appeared already stale
+	NewStaleAMIs []string `json:"newStaleAmis"`
+	// ChangedParameters are the parameters added, removed or updated, as region:name
+	ChangedParameters []string `json:"changedParameters"`
+}
+
+// validateSchedule checks a cron expression: five fields or a descriptor like @daily
+func validateSchedule(schedule string) error {
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return nil
+}
+
+// startScheduler schedules the presets that have a cron expression
+func (a *App) startScheduler() {
+	a.mu.Lock()
+	a.cron = cron.New()
+	a.scheduled = make(map[string]cron.EntryID)
+	a.mu.Unlock()
+
+	presets, err := a.ListPresets()
+	if err != nil {
+		slog.Warn("Unable to load the scheduled presets", "err", err)
+	}
+	for _, p := range presets {
+		a.schedulePreset(p)
+	}
+	a.cron.Start()
+}
+
+// stopScheduler stops the scheduler without waiting for the running scans
+func (a *App) stopScheduler() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cron != nil {
+		a.cron.Stop()
+	}
+}
+
+// schedulePreset (re)schedules a preset, or unschedules it when its schedule is empty
+func (a *App) schedulePreset(preset Preset) {
+	a.unschedulePreset(preset.Name)
+	if preset.Schedule == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cron == nil {
+		return
+	}
+	name := preset.Name
+	id, err := a.cron.AddFunc(preset.Schedule, func() { a.runScheduledScan(name) })
+	if err != nil {
+		slog.Warn("Unable to schedule preset", "preset", name, "err", err)
+		return
+	}
+	a.scheduled[name] = id
+}
+
+func (a *App) unschedulePreset(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if id, ok := a.scheduled[name]; ok {
+		a.cron.Remove(id)
+		delete(a.scheduled, name)
+	}
+}
+
+// NextScheduledRun returns when the preset runs next (RFC 3339), "" when it isn't scheduled
+func (a *App) NextScheduledRun(name string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id, ok := a.scheduled[name]
+	if !ok {
+		return ""
+	}
+	return a.cron.Entry(id).Next.UTC().Format(time.RFC3339)
+}
+
+// runScheduledScan rescans a preset, sends its summary to the notifiers and raises
+// an alert on what changed since the previous scan of the same profile and regions
+func (a *App) runScheduledScan(name string) {
+	preset, err := a.GetPreset(name)
+	if err != nil {
+		slog.Warn("Unable to run scheduled preset", "preset", name, "err", err)
+		return
+	}
+	req := preset.Request
+	req.RequestID = scheduledRequestPrefix + name
+	req.ForceRefresh = true
+
+	previous := a.latestScan(req.Profile, req.Regions)
+	result, err := a.ProcessRequest(req)
+	if err != nil {
+		slog.Warn("Scheduled scan failed", "preset", name, "err", err)
+		return
+	}
+	var previousResult *AWSResult
+	if previous != nil {
+		previousResult = previous.Result
+	}
+	a.notify(newScanNotification(name, req.Profile, previousResult, result))
+	if previous == nil {
+		return
+	}
+
+	alert := ScheduleAlert{
+		Preset:            name,
+		Profile:           req.Profile,
+		ScannedAt:         result.ScannedAt,
+		NewStaleAMIs:      newStaleAMIs(previous.Result, result),
+		ChangedParameters: changedParameters(previous.Result, result),
+	}
+	if len(alert.NewStaleAMIs) > 0 || len(alert.ChangedParameters) > 0 {
+		a.emit("schedule:alert", alert)
+	}
+}
+
+// newStaleAMIs returns the AMIs stale in the current result that weren't before
+func newStaleAMIs(before, after *AWSResult) []string {
+	stale := func(r *AWSResult) map[string]bool {
+		amis := make(map[string]bool)
+		for _, inst := range r.Instances {
+			if inst.AMIStale && inst.AMI != "" {
+				amis[inst.AMI] = true
+			}
+		}
+		return amis
+	}
+	was := stale(before)
+	var amis []string
+	for ami := range stale(after) {
+		if !was[ami] {
+			amis = append(amis, ami)
+		}
+	}
+	sort.Strings(amis)
+	return amis
+}
+
+// changedParameters returns the parameters added, removed or with a new version, as region:name
+func changedParameters(before, after *AWSResult) []string {
+	var changed []string
+	for _, c := range parameterChanges(before, after) {
+		changed = append(changed, c.Region+":"+c.Name)
+	}
+	return changed
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// SecretInfo is a Secrets Manager secret found by a scan, without its value
+type SecretInfo struct {
+	Name            string `json:"name"`
+	ARN             string `json:"arn"`
+	Region          string `json:"region"`
+	Description     string `json:"description"`
+	KMSKeyID        string `json:"kmsKeyId"`
+	RotationEnabled bool   `json:"rotationEnabled"`
+	LastChangedDate string `json:"lastChangedDate"`
+	// LastAccessedDate is only tracked to the day by Secrets Manager
+	LastAccessedDate string `json:"lastAccessedDate"`
+}
+
+// listSecrets returns the secrets matching the same SSMFilter as the parameters.
+// Prefixes are matched by Secrets Manager (its name filter is a prefix match);
+// contains, regex and tag pairs are matched client-side. Types and KeyID don't apply.
+func (a *App) listSecrets(ctx context.Context, client SecretsManagerAPI, region string, filter SSMFilter) ([]SecretInfo, error) {
+	match, err := filter.nameMatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	in := &secretsmanager.ListSecretsInput{}
+	if filter.Match == "" || filter.Match == MatchPrefix {
+		var prefixes []string
+		for _, p := range filter.patterns() {
+			if p = strings.TrimRight(p, "*"); p != "" {
+				prefixes = append(prefixes, p)
+			}
+		}
+		if len(prefixes) > 0 {
+			in.Filters = []smtypes.Filter{{Key: smtypes.FilterNameStringTypeName, Values: prefixes}}
+		}
+	}
+
+	var secrets []SecretInfo
+	paginator := secretsmanager.NewListSecretsPaginator(client, in)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		for _, s := range page.SecretList {
+			name := aws.ToString(s.Name)
+			if match != nil && !match(name) {
+				continue
+			}
+			if !secretTagsMatch(s.Tags, filter.Tags) {
+				continue
+			}
+			info := SecretInfo{
+				Name:            name,
+				ARN:             aws.ToString(s.ARN),
+				Region:          region,
+				Description:     aws.ToString(s.Description),
+				KMSKeyID:        aws.ToString(s.KmsKeyId),
+				RotationEnabled: aws.ToBool(s.RotationEnabled),
+			}
+			if s.LastChangedDate != nil {
+				info.LastChangedDate = s.LastChangedDate.UTC().Format(time.RFC3339)
+			}
+			if s.LastAccessedDate != nil {
+				info.LastAccessedDate = s.LastAccessedDate.UTC().Format(time.DateOnly)
+			}
+			secrets = append(secrets, info)
+		}
+	}
+	return secrets, nil
+}
+
+// secretTagsMatch applies the SSMFilter tag rules to the tags of a secret:
+// values given for the same key are ORed, different keys are ANDed, bare keys are ORed together
+func secretTagsMatch(tags []smtypes.Tag, filterTags []string) bool {
+	if len(filterTags) == 0 {
+		return true
+	}
+	have := make(map[string]string, len(tags))
+	for _, t := range tags {
+		have[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+
+	wanted := make(map[string][]string)
+	var bareKeys []string
+	for _, t := range filterTags {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(t), "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "tag:"))
+		if key == "" {
+			continue
+		}
+		if !hasValue {
+			bareKeys = append(bareKeys, key)
+			continue
+		}
+		wanted[key] = append(wanted[key], strings.TrimSpace(value))
+	}
+
+	if len(bareKeys) > 0 {
+		found := false
+		for _, k := range bareKeys {
+			if _, ok := have[k]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, values := range wanted {
+		v, ok := have[key]
+		if !ok {
+			return false
+		}
+		found := false
+		for _, want := range values {
+			if v == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
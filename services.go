@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ServiceResource is a resource of another service scanned alongside the instances,
+// e.g. an RDS database. Flagged resources carry the reason in Finding.
+type ServiceResource struct {
+	Service string `json:"service"`
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	ARN     string `json:"arn"`
+	Region  string `json:"region"`
+	// Version is what the check looks at: engine version, image tag, release...
+	Version string `json:"version"`
+	Status  string `json:"status"`
+	Flagged bool   `json:"flagged"`
+	Finding string `json:"finding,omitempty"`
+	// Details holds the service specific attributes shown in the tooltip
+	Details map[string]string `json:"details,omitempty"`
+	// Targets are the EC2 instances behind the resource, e.g. registered in a target group
+	Targets []string `json:"targets,omitempty"`
+}
+
+// serviceScanner lists the resources of one service in the region of cfg
+type serviceScanner func(a *App, ctx context.Context, cfg aws.Config, req ProcessingRequest) ([]ServiceResource, error)
+
+// serviceScanners are the services that can be requested in ProcessingRequest.Services
+var serviceScanners = map[string]serviceScanner{
+	"rds": (*App).scanRDS,
+	"ecs": (*App).scanECS,
+	"eks": (*App).scanEKS,
+	"elb": (*App).scanELB,
+	"s3":  (*App).scanS3,
+}
+
+// validateServices checks that every requested service has a scanner
+func validateServices(services []string) error {
+	for _, s := range services {
+		if _, ok := serviceScanners[s]; !ok {
+			known := make([]string, 0, len(serviceScanners))
+			for name := range serviceScanners {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("invalid service %q: must be one of %s", s, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// scanServices runs the requested service scanners and returns their resources by service.
+// A failing service is logged and left out.
+func (a *App) scanServices(ctx context.Context, cfg aws.Config, req ProcessingRequest) map[string][]ServiceResource {
+	results := make(map[string][]ServiceResource)
+	for _, s := range req.Services {
+		resources, err := serviceScanners[s](a, ctx, cfg, req)
+		if err != nil {
+			slog.Warn("Unable to scan service", "service", s, "err", err)
+			continue
+		}
+		results[s] = resources
+	}
+	return results
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// sesNotifier emails the HTML report of each scheduled scan through SES, with
+// the credentials and region of its profile
+type sesNotifier struct {
+	app     *App
+	profile string
+	from    string
+	to      []string
+}
+
+func newSESNotifier(a *App, profile, from string, to []string) (*sesNotifier, error) {
+	if profile == "" {
+		return nil, fmt.Errorf("email reports need the profile to send with")
+	}
+	if err := validateEmailAddresses(from, to); err != nil {
+		return nil, err
+	}
+	return &sesNotifier{app: a, profile: profile, from: from, to: to}, nil
+}
+
+func (s *sesNotifier) name() string {
+	return "SES"
+}
+
+func (s *sesNotifier) notify(ctx context.Context, n ScanNotification) error {
+	if n.Result == nil {
+		// Test notification: no scan to report
+		body := fmt.Sprintf("<p>goCheckAmi can send reports from %s with profile %s.</p>", s.from, s.profile)
+		return s.app.sendEmail(ctx, s.profile, s.from, s.to, "goCheckAmi test email", body)
+	}
+	subject := fmt.Sprintf("goCheckAmi report %s (%s): %d stale AMI(s), %d deprecated", n.Preset, n.Profile, n.StaleAMIs, n.DeprecatedAMIs)
+	return s.app.sendReportEmail(ctx, s.profile, s.from, s.to, subject, *n.Result)
+}
+
+// validateEmailAddresses checks the sender and recipients of an email
+func validateEmailAddresses(from string, to []string) error {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("email reports need at least one recipient")
+	}
+	for _, addr := range to {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// EmailReport sends the HTML report of a result through SES, with the email
+// profile and sender of the settings. Without recipients, the recipients of
+// the settings get it.
+func (a *App) EmailReport(result AWSResult, to []string) error {
+	prefs, err := a.LoadUserPrefs()
+	if err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		to = prefs.EmailTo
+	}
+	if prefs.EmailProfile == "" {
+		return fmt.Errorf("email reports need the profile to send with, set in the settings")
+	}
+	if err := validateEmailAddresses(prefs.EmailFrom, to); err != nil {
+		return err
+	}
+	return a.sendReportEmail(a.ctx, prefs.EmailProfile, prefs.EmailFrom, to, "goCheckAmi report", result)
+}
+
+// sendReportEmail renders the HTML report of result and emails it
+func (a *App) sendReportEmail(ctx context.Context, profile, from string, to []string, subject string, result AWSResult) error {
+	html, err := renderReport(result, ReportHTML, time.Now())
+	if err != nil {
+		return err
+	}
+	return a.sendEmail(ctx, profile, from, to, subject, string(html))
+}
+
+// sendEmail sends an HTML email through SES in the profile's region. The sender
+// must be a verified identity of the account.
+func (a *App) sendEmail(ctx context.Context, profile, from string, to []string, subject, html string) error {
+	cfg, err := a.authenticate(ctx, profile)
+	if err != nil {
+		return err
+	}
+	_, err = sesv2.NewFromConfig(cfg).SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(from),
+		Destination:      &sestypes.Destination{ToAddresses: to},
+		Content: &sestypes.EmailContent{
+			Simple: &sestypes.Message{
+				Subject: &sestypes.Content{Data: aws.String(subject), Charset: aws.String("UTF-8")},
+				Body: &sestypes.Body{
+					Html: &sestypes.Content{Data: aws.String(html), Charset: aws.String("UTF-8")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email with SES: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+)
+
+// SessionStatus describes the cached SSO token of a profile
+type SessionStatus struct {
+	Profile string `json:"profile"`
+	// SSO is false for profiles authenticating without IAM Identity Center
+	SSO bool `json:"sso"`
+	// LoggedIn is set when a token is cached, even an expired one
+	LoggedIn bool `json:"loggedIn"`
+	Valid    bool `json:"valid"`
+	// ExpiresAt is the RFC 3339 expiry of the token; ExpiresInMinutes is 0 once expired
+	ExpiresAt        string `json:"expiresAt"`
+	ExpiresInMinutes int    `json:"expiresInMinutes"`
+}
+
+// GetSessionStatus reports whether the cached SSO token of a profile is valid and
+// when it expires, so the UI can prompt a login before a scan fails
+func (a *App) GetSessionStatus(profile string) (*SessionStatus, error) {
+	status := &SessionStatus{Profile: profile}
+	settings, ok := a.profileSSOSettings(a.ssoRootProfile(profile))
+	if !ok {
+		return status, nil
+	}
+	status.SSO = true
+
+	path, err := ssocreds.StandardCachedTokenFilepath(settings.cacheKey())
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sso token: %w", err)
+	}
+
+	var cached struct {
+		AccessToken string `json:"accessToken"`
+		ExpiresAt   string `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse sso token: %w", err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, cached.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sso token expiry: %w", err)
+	}
+
+	status.LoggedIn = cached.AccessToken != ""
+	status.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	if remaining := time.Until(expiresAt); status.LoggedIn && remaining > 0 {
+		status.Valid = true
+		status.ExpiresInMinutes = int(remaining.Minutes())
+	}
+	return status, nil
+}
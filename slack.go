@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// slackNotifier posts the notifications to a Slack incoming webhook
+type slackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func newSlackNotifier(webhookURL string) (*slackNotifier, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid Slack webhook URL %q: must be an https URL", webhookURL)
+	}
+	return &slackNotifier{webhookURL: webhookURL, client: http.DefaultClient}, nil
+}
+
+func (s *slackNotifier) name() string {
+	return "Slack"
+}
+
+func (s *slackNotifier) notify(ctx context.Context, n ScanNotification) error {
+	body, err := json.Marshal(map[string]string{"text": slackMessage(n)})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// Slack explains the failure in the body, e.g. invalid_payload or no_service
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// slackMessage renders the notification in Slack mrkdwn
+func slackMessage(n ScanNotification) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*goCheckAmi* scheduled scan *%s* (profile `%s`, %s)\n", n.Preset, n.Profile, n.ScannedAt)
+	fmt.Fprintf(&b, "• %d instance(s)\n", n.Instances)
+	fmt.Fprintf(&b, "• %d stale AMI(s)", n.StaleAMIs)
+	if len(n.NewStaleAMIs) > 0 {
+		fmt.Fprintf(&b, ", newly stale: %s", strings.Join(n.NewStaleAMIs, ", "))
+	}
+	fmt.Fprintf(&b, "\n• %d deprecated AMI(s)\n", n.DeprecatedAMIs)
+	fmt.Fprintf(&b, "• %d parameter change(s)", n.ParameterChanges)
+	return b.String()
+}
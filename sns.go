@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"goCheckAmi/policy"
+)
+
+// maxSNSMessageBytes is the largest message SNS accepts
+const maxSNSMessageBytes = 256 * 1024
+
+// Message types, in the "type" attribute of the published messages so that
+// subscriptions can filter on them
+const (
+	SNSMessageScan       = "scan"
+	SNSMessageViolations = "violations"
+)
+
+// SNSViolations is the message published instead of the whole scan when only
+// violations are requested: the instances failing a --fail-on check and the policy results
+type SNSViolations struct {
+	Preset        string          `json:"preset"`
+	Profile       string          `json:"profile"`
+	ScannedAt     string          `json:"scannedAt"`
+	Instances     []EC2Instance   `json:"instances"`
+	PolicyResults []policy.Result `json:"policyResults"`
+}
+
+// snsNotifier publishes each scheduled scan, or only its violations, to an SNS topic
+type snsNotifier struct {
+	app            *App
+	profile        string
+	topicARN       arn.ARN
+	violationsOnly bool
+}
+
+func newSNSNotifier(a *App, profile, topicARN string, violationsOnly bool) (*snsNotifier, error) {
+	if profile == "" {
+		return nil, fmt.Errorf("SNS publishing needs the profile to publish with")
+	}
+	parsed, err := arn.Parse(topicARN)
+	if err != nil || parsed.Service != "sns" {
+		return nil, fmt.Errorf("invalid SNS topic ARN %q", topicARN)
+	}
+	return &snsNotifier{app: a, profile: profile, topicARN: parsed, violationsOnly: violationsOnly}, nil
+}
+
+func (s *snsNotifier) name() string {
+	return "SNS"
+}
+
+func (s *snsNotifier) notify(ctx context.Context, n ScanNotification) error {
+	if n.Result == nil {
+		// Test notification: publish the summary
+		return s.app.publishSNS(ctx, s.profile, s.topicARN, SNSMessageScan, n)
+	}
+	if s.violationsOnly {
+		return s.app.publishSNS(ctx, s.profile, s.topicARN, SNSMessageViolations, newSNSViolations(n))
+	}
+	return s.app.publishSNS(ctx, s.profile, s.topicARN, SNSMessageScan, n.Result)
+}
+
+// PublishResult publishes a result of the profile, or only its violations, to the
+// SNS topic of the settings, like the scheduled scans are
+func (a *App) PublishResult(result AWSResult, profile string) error {
+	prefs, err := a.LoadUserPrefs()
+	if err != nil {
+		return err
+	}
+	if prefs.SNSTopicARN == "" {
+		return fmt.Errorf("no SNS topic is set in the settings")
+	}
+	s, err := newSNSNotifier(a, prefs.SNSProfile, prefs.SNSTopicARN, prefs.SNSViolationsOnly)
+	if err != nil {
+		return err
+	}
+	return s.notify(a.ctx, newScanNotification("", profile, nil, &result))
+}
+
+// newSNSViolations keeps the instances matching any --fail-on check and the policy results
+func newSNSViolations(n ScanNotification) SNSViolations {
+	v := SNSViolations{
+		Preset:        n.Preset,
+		Profile:       n.Profile,
+		ScannedAt:     n.ScannedAt,
+		Instances:     []EC2Instance{},
+		PolicyResults: n.Result.PolicyResults,
+	}
+	for _, inst := range n.Result.Instances {
+		for _, match := range failOnChecks {
+			if match(inst) {
+				v.Instances = append(v.Instances, inst)
+				break
+			}
+		}
+	}
+	return v
+}
+
+// publishSNS publishes message as JSON to the topic, with the credentials of the
+// profile in the region of the topic
+func (a *App) publishSNS(ctx context.Context, profile string, topic arn.ARN, messageType string, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode SNS message: %w", err)
+	}
+	if len(data) > maxSNSMessageBytes {
+		return fmt.Errorf("SNS message of %d KB exceeds the limit of %d KB, publish only the violations", len(data)/1024, maxSNSMessageBytes/1024)
+	}
+
+	cfg, err := a.authenticate(ctx, profile)
+	if err != nil {
+		return err
+	}
+	cfg.Region = topic.Region
+	_, err = sns.NewFromConfig(cfg).Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(topic.String()),
+		Message:  aws.String(string(data)),
+		MessageAttributes: map[string]snstypes.MessageAttributeValue{
+			"type": {DataType: aws.String("String"), StringValue: aws.String(messageType)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+	return nil
+}
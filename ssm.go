@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// Name match modes of SSMFilter
+const (
+	MatchPrefix   = "prefix"
+	MatchContains = "contains"
+	MatchRegex    = "regex"
+)
+
+// SSMFilter narrows the DescribeParameters lookup.
+// Only the fields that are set become filters.
+type SSMFilter struct {
+	// NamePrefix holds comma-separated name patterns, interpreted according to Match
+	NamePrefix string `json:"namePrefix"`
+	// Match is prefix (the default, matched by SSM), contains or regex.
+	// contains and regex are applied client-side after listing.
+	Match string   `json:"match"`
+	Types []string `json:"types"`
+	KeyID string   `json:"keyId"`
+	// Tags are "Key=Value" pairs, or a bare "Key" for parameters that have the tag.
+	// Values given for the same key are ORed, different keys are ANDed;
+	// bare keys are ORed together (a single tag-key filter).
+	Tags []string `json:"tags"`
+}
+
+// ParameterInfo is a parameter found by a scan, without its value
+type ParameterInfo struct {
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Type    string `json:"type"`
+	Version int64  `json:"version"`
+	// Tier (Standard, Advanced, Intelligent-Tiering) and LastModifiedUser are
+	// not returned by GetParametersByPath, so they are empty in path mode
+	Tier             string `json:"tier"`
+	LastModifiedDate string `json:"lastModifiedDate"`
+	LastModifiedUser string `json:"lastModifiedUser"`
+	// Pinned is set for the favorite parameters, listed first
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// parameterInfoFromMetadata converts a parameter returned by DescribeParameters
+func parameterInfoFromMetadata(p ssmtypes.ParameterMetadata, region string) ParameterInfo {
+	info := ParameterInfo{
+		Name:             aws.ToString(p.Name),
+		Region:           region,
+		Type:             string(p.Type),
+		Version:          p.Version,
+		Tier:             string(p.Tier),
+		LastModifiedUser: aws.ToString(p.LastModifiedUser),
+	}
+	if p.LastModifiedDate != nil {
+		info.LastModifiedDate = p.LastModifiedDate.UTC().Format(time.RFC3339)
+	}
+	return info
+}
+
+// parameterInfoFromParameter converts a parameter returned by GetParametersByPath
+func parameterInfoFromParameter(p ssmtypes.Parameter, region string) ParameterInfo {
+	info := ParameterInfo{
+		Name:    aws.ToString(p.Name),
+		Region:  region,
+		Type:    string(p.Type),
+		Version: p.Version,
+	}
+	if p.LastModifiedDate != nil {
+		info.LastModifiedDate = p.LastModifiedDate.UTC().Format(time.RFC3339)
+	}
+	return info
+}
+
+// validParameterTypes are the parameter types accepted by the Type filter
+var validParameterTypes = map[string]bool{
+	string(ssmtypes.ParameterTypeString):       true,
+	string(ssmtypes.ParameterTypeStringList):   true,
+	string(ssmtypes.ParameterTypeSecureString): true,
+}
+
+// buildParameterFilters converts an SSMFilter into DescribeParameters filters.
+// ParameterFilters are used rather than the legacy Filters, which can't filter on tags;
+// the two can't be mixed in one call.
+func buildParameterFilters(filter SSMFilter) ([]ssmtypes.ParameterStringFilter, error) {
+	var filters []ssmtypes.ParameterStringFilter
+
+	if len(filter.Types) > 0 {
+		for _, t := range filter.Types {
+			if !validParameterTypes[t] {
+				return nil, fmt.Errorf("invalid parameter type %q: must be one of String, StringList, SecureString", t)
+			}
+		}
+		filters = append(filters, ssmtypes.ParameterStringFilter{
+			Key:    aws.String("Type"),
+			Values: filter.Types,
+		})
+	}
+
+	if filter.KeyID != "" {
+		filters = append(filters, ssmtypes.ParameterStringFilter{
+			Key:    aws.String("KeyId"),
+			Values: []string{filter.KeyID},
+		})
+	}
+
+	tagFilters, err := buildParameterTagFilters(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, tagFilters...)
+
+	if filter.Match != "" && filter.Match != MatchPrefix {
+		// Matched client-side by nameMatcher
+		return filters, nil
+	}
+
+	// User said: "considere um wildcard no fim do filtro mas nao no inicio" -> prefix match.
+	// So if user types "prod" or "prod*", we search for names beginning with "prod".
+	// An empty prefix matches everything and needs no filter.
+	var prefixes []string
+	for _, p := range filter.patterns() {
+		if p = strings.TrimRight(p, "*"); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	if len(prefixes) > 0 {
+		filters = append(filters, ssmtypes.ParameterStringFilter{
+			Key:    aws.String("Name"),
+			Option: aws.String("BeginsWith"),
+			Values: prefixes,
+		})
+	}
+	return filters, nil
+}
+
+// patterns splits NamePrefix on commas
+func (f SSMFilter) patterns() []string {
+	var patterns []string
+	for _, p := range strings.Split(f.NamePrefix, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// nameMatcher returns the client-side name test of the contains and regex modes,
+// or nil when SSM already filters the names. A name matching any pattern is kept.
+func (f SSMFilter) nameMatcher() (func(string) bool, error) {
+	patterns := f.patterns()
+	switch f.Match {
+	case "", MatchPrefix:
+		return nil, nil
+	case MatchContains:
+		if len(patterns) == 0 {
+			return nil, nil
+		}
+		return func(name string) bool {
+			for _, p := range patterns {
+				if strings.Contains(name, p) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case MatchRegex:
+		if len(patterns) == 0 {
+			return nil, nil
+		}
+		res := make([]*regexp.Regexp, len(patterns))
+		for i, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid name regex %q: %w", p, err)
+			}
+			res[i] = re
+		}
+		return func(name string) bool {
+			for _, re := range res {
+				if re.MatchString(name) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid match mode %q: must be one of prefix, contains, regex", f.Match)
+	}
+}
+
+// usePathMode reports whether the parameters can be listed with GetParametersByPath,
+// which is much faster than DescribeParameters on hierarchical stores.
+// That API doesn't support tag filters and takes a single path.
+func (f SSMFilter) usePathMode() bool {
+	if f.Match != "" && f.Match != MatchPrefix {
+		return false
+	}
+	patterns := f.patterns()
+	return len(patterns) == 1 && strings.HasPrefix(patterns[0], "/") && len(f.Tags) == 0
+}
+
+// parameterPath splits a "/" prefix into the hierarchy to list recursively and the
+// name prefix to keep: "/app/pro" lists "/app" and keeps the names beginning with "/app/pro"
+func parameterPath(prefix string) (path, namePrefix string) {
+	namePrefix = strings.TrimRight(prefix, "*")
+	path = namePrefix[:strings.LastIndex(namePrefix, "/")+1]
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path, namePrefix
+}
+
+// buildPathFilters converts an SSMFilter into GetParametersByPath filters (Type and KeyId only)
+func buildPathFilters(filter SSMFilter) ([]ssmtypes.ParameterStringFilter, error) {
+	return buildParameterFilters(SSMFilter{Types: filter.Types, KeyID: filter.KeyID})
+}
+
+// parameterFilters returns the filters of the listing API picked by usePathMode.
+// It also validates the client-side patterns.
+func (f SSMFilter) parameterFilters() ([]ssmtypes.ParameterStringFilter, error) {
+	if _, err := f.nameMatcher(); err != nil {
+		return nil, err
+	}
+	if f.usePathMode() {
+		return buildPathFilters(f)
+	}
+	return buildParameterFilters(f)
+}
+
+// buildParameterTagFilters turns "Key=Value" and "Key" entries into tag filters
+func buildParameterTagFilters(tags []string) ([]ssmtypes.ParameterStringFilter, error) {
+	var filters []ssmtypes.ParameterStringFilter
+	values := make(map[string][]string)
+	var keys, bareKeys []string
+	for _, t := range tags {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(t), "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "tag:"))
+		if key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q: expected Key=Value or Key", t)
+		}
+		if !hasValue {
+			bareKeys = append(bareKeys, key)
+			continue
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], strings.TrimSpace(value))
+	}
+
+	if len(bareKeys) > 0 {
+		filters = append(filters, ssmtypes.ParameterStringFilter{
+			Key:    aws.String("tag-key"),
+			Values: bareKeys,
+		})
+	}
+	for _, k := range keys {
+		filters = append(filters, ssmtypes.ParameterStringFilter{
+			Key:    aws.String("tag:" + k),
+			Option: aws.String("Equals"),
+			Values: values[k],
+		})
+	}
+	return filters, nil
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	ssooidctypes "github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+)
+
+// ssoClientName is the OIDC client registered for the device authorization
+const ssoClientName = "goCheckAmi"
+
+// ssoDeviceGrantType is the OAuth grant polled with CreateToken
+const ssoDeviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// ssoSlowDownStep is added to the polling interval when the service asks to slow down
+const ssoSlowDownStep = 5 * time.Second
+
+// SSOLoginPrompt is the payload of the sso:login event: the user approves the
+// login by opening VerificationURIComplete, or VerificationURI and typing UserCode
+type SSOLoginPrompt struct {
+	Profile                 string `json:"profile"`
+	VerificationURI         string `json:"verificationUri"`
+	VerificationURIComplete string `json:"verificationUriComplete"`
+	UserCode                string `json:"userCode"`
+	ExpiresAt               string `json:"expiresAt"`
+}
+
+// SSOSession describes the IAM Identity Center login of an SSO-backed profile, see ProfileInfo.
+// Name is the [sso-session] block, empty for legacy profiles with an inline sso_start_url.
+type SSOSession struct {
+	Profile  string `json:"profile"`
+	Name     string `json:"name"`
+	StartURL string `json:"startUrl"`
+	Region   string `json:"region"`
+}
+
+// ssoSettings are the IAM Identity Center settings of a profile, either inline
+// (legacy sso_start_url) or from the [sso-session] it references
+type ssoSettings struct {
+	startURL string
+	region   string
+	session  string
+}
+
+// cacheKey is the key the SDK hashes to find the cached token
+func (s ssoSettings) cacheKey() string {
+	if s.session != "" {
+		return s.session
+	}
+	return s.startURL
+}
+
+// ssoSessionValue reads a key of an [sso-session name] section of the shared config file
+func (a *App) ssoSessionValue(session, key string) string {
+	cfg, err := a.loadAWSConfigFile()
+	if err != nil {
+		return ""
+	}
+	return cfg.Section("sso-session " + session).Key(key).String()
+}
+
+// profileSSOSettings returns the SSO settings of the profile, ok=false when it isn't SSO backed
+func (a *App) profileSSOSettings(profile string) (ssoSettings, bool) {
+	if session := a.getProfileValue(profile, "sso_session"); session != "" {
+		s := ssoSettings{
+			startURL: a.ssoSessionValue(session, "sso_start_url"),
+			region:   a.ssoSessionValue(session, "sso_region"),
+			session:  session,
+		}
+		return s, s.startURL != "" && s.region != ""
+	}
+	s := ssoSettings{
+		startURL: a.getProfileValue(profile, "sso_start_url"),
+		region:   a.getProfileValue(profile, "sso_region"),
+	}
+	return s, s.startURL != "" && s.region != ""
+}
+
+// ssoRootProfile returns the profile whose credentials a role chain starts from,
+// the one that needs an SSO login, or the profile itself when it assumes no role
+func (a *App) ssoRootProfile(profile string) string {
+	if hops, _ := a.roleChain(profile); len(hops) > 0 {
+		return a.getProfileValue(hops[0].profile, "source_profile")
+	}
+	return profile
+}
+
+// ssoLogin runs the OIDC device authorization flow for the profile: the
+// verification URL and code are sent to the frontend as an sso:login event,
+// then the token is polled and written to the SDK's SSO cache
+func (a *App) ssoLogin(ctx context.Context, profile string, settings ssoSettings) error {
+	client := ssooidc.New(ssooidc.Options{Region: settings.region})
+
+	reg, err := client.RegisterClient(ctx, &ssooidc.RegisterClientInput{
+		ClientName: aws.String(ssoClientName),
+		ClientType: aws.String("public"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register sso client: %w", err)
+	}
+
+	auth, err := client.StartDeviceAuthorization(ctx, &ssooidc.StartDeviceAuthorizationInput{
+		ClientId:     reg.ClientId,
+		ClientSecret: reg.ClientSecret,
+		StartUrl:     aws.String(settings.startURL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start sso device authorization: %w", err)
+	}
+
+	deadline := time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	a.emit("sso:login", SSOLoginPrompt{
+		Profile:                 profile,
+		VerificationURI:         aws.ToString(auth.VerificationUri),
+		VerificationURIComplete: aws.ToString(auth.VerificationUriComplete),
+		UserCode:                aws.ToString(auth.UserCode),
+		ExpiresAt:               deadline.UTC().Format(time.RFC3339),
+	})
+	defer a.emit("sso:done", profile)
+
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = ssoSlowDownStep
+	}
+	for {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("sso login for profile %q was not approved in time", profile)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		tok, err := client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+			ClientId:     reg.ClientId,
+			ClientSecret: reg.ClientSecret,
+			DeviceCode:   auth.DeviceCode,
+			GrantType:    aws.String(ssoDeviceGrantType),
+		})
+		var pending *ssooidctypes.AuthorizationPendingException
+		var slowDown *ssooidctypes.SlowDownException
+		switch {
+		case errors.As(err, &pending):
+			continue
+		case errors.As(err, &slowDown):
+			interval += ssoSlowDownStep
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to create sso token: %w", err)
+		}
+		return writeSSOToken(settings, reg, tok)
+	}
+}
+
+// writeSSOToken stores the token where the SDK (and the AWS CLI) look for it
+func writeSSOToken(settings ssoSettings, reg *ssooidc.RegisterClientOutput, tok *ssooidc.CreateTokenOutput) error {
+	path, err := ssocreds.StandardCachedTokenFilepath(settings.cacheKey())
+	if err != nil {
+		return err
+	}
+
+	cached := map[string]interface{}{
+		"startUrl":    settings.startURL,
+		"region":      settings.region,
+		"accessToken": aws.ToString(tok.AccessToken),
+		"expiresAt":   time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second).UTC().Format(time.RFC3339),
+	}
+	// sso-session tokens carry the client so the SDK can refresh them
+	if settings.session != "" && tok.RefreshToken != nil {
+		cached["refreshToken"] = aws.ToString(tok.RefreshToken)
+		cached["clientId"] = aws.ToString(reg.ClientId)
+		cached["clientSecret"] = aws.ToString(reg.ClientSecret)
+		cached["registrationExpiresAt"] = time.Unix(reg.ClientSecretExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode sso token: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create sso cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write sso token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EBS snapshot list prices (us-east-1, USD per GiB-month). Snapshots are billed
+// incrementally, so the full size gives an upper bound of the real cost.
+const (
+	snapshotStandardGiBMonthUSD = 0.05
+	snapshotArchiveGiBMonthUSD  = 0.0125
+)
+
+// SnapshotInfo is an EBS snapshot backing an AMI
+type SnapshotInfo struct {
+	ID             string  `json:"id"`
+	SizeGiB        int32   `json:"sizeGiB"`
+	StorageTier    string  `json:"storageTier"`
+	MonthlyCostUSD float64 `json:"monthlyCostUsd"`
+}
+
+// AMIStorage is the storage used by one owned AMI
+type AMIStorage struct {
+	Image          AMIImage       `json:"image"`
+	Region         string         `json:"region"`
+	Snapshots      []SnapshotInfo `json:"snapshots"`
+	SizeGiB        int32          `json:"sizeGiB"`
+	MonthlyCostUSD float64        `json:"monthlyCostUsd"`
+}
+
+// AmiStorageReport estimates what the AMIs owned by the account cost to keep
+type AmiStorageReport struct {
+	Images              []AMIStorage `json:"images"`
+	TotalSizeGiB        int32        `json:"totalSizeGiB"`
+	TotalMonthlyCostUSD float64      `json:"totalMonthlyCostUsd"`
+}
+
+// merge appends the images of another region's report
+func (r *AmiStorageReport) merge(other *AmiStorageReport) {
+	r.Images = append(r.Images, other.Images...)
+	r.TotalSizeGiB += other.TotalSizeGiB
+	r.TotalMonthlyCostUSD += other.TotalMonthlyCostUSD
+}
+
+// amiStorageReport resolves the snapshots of every AMI owned by the account in the region
+func amiStorageReport(ctx context.Context, client EC2API, region string) (*AmiStorageReport, error) {
+	owned, err := ownedAMIs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshotIDs []string
+	for _, img := range owned {
+		ids, _ := imageSnapshots(img)
+		snapshotIDs = append(snapshotIDs, ids...)
+	}
+	snapshots, err := describeSnapshots(ctx, client, snapshotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AmiStorageReport{Images: []AMIStorage{}}
+	for _, img := range owned {
+		entry := AMIStorage{Image: toAMIImage(img), Region: region, Snapshots: []SnapshotInfo{}}
+		ids, _ := imageSnapshots(img)
+		for _, id := range ids {
+			snap, ok := snapshots[id]
+			if !ok {
+				// Shared or already deleted snapshot, nothing billed to us
+				continue
+			}
+			info := SnapshotInfo{
+				ID:          id,
+				SizeGiB:     aws.ToInt32(snap.VolumeSize),
+				StorageTier: string(snap.StorageTier),
+			}
+			price := snapshotStandardGiBMonthUSD
+			if snap.StorageTier == ec2types.StorageTierArchive {
+				price = snapshotArchiveGiBMonthUSD
+			}
+			info.MonthlyCostUSD = float64(info.SizeGiB) * price
+			entry.Snapshots = append(entry.Snapshots, info)
+			entry.SizeGiB += info.SizeGiB
+			entry.MonthlyCostUSD += info.MonthlyCostUSD
+		}
+		report.Images = append(report.Images, entry)
+		report.TotalSizeGiB += entry.SizeGiB
+		report.TotalMonthlyCostUSD += entry.MonthlyCostUSD
+	}
+	return report, nil
+}
+
+// describeSnapshotsBatchSize keeps the snapshot-id filter within the API limits
+const describeSnapshotsBatchSize = 200
+
+// describeSnapshots describes the snapshots owned by the account, keyed by ID.
+// A filter is used so that deleted snapshots are skipped instead of failing the call.
+func describeSnapshots(ctx context.Context, client ec2.DescribeSnapshotsAPIClient, ids []string) (map[string]ec2types.Snapshot, error) {
+	snapshots := make(map[string]ec2types.Snapshot, len(ids))
+	for start := 0; start < len(ids); start += describeSnapshotsBatchSize {
+		end := min(start+describeSnapshotsBatchSize, len(ids))
+		pager := ec2.NewDescribeSnapshotsPaginator(client, &ec2.DescribeSnapshotsInput{
+			OwnerIds: []string{"self"},
+			Filters: []ec2types.Filter{
+				{Name: aws.String("snapshot-id"), Values: ids[start:end]},
+			},
+		})
+		for pager.HasMorePages() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe snapshots: %w", err)
+			}
+			for _, s := range page.Snapshots {
+				snapshots[aws.ToString(s.SnapshotId)] = s
+			}
+		}
+	}
+	return snapshots, nil
+}
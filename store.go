@@ -0,0 +1,120 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	_ "modernc.org/sqlite"
+)
+
+// storeOpenTimeout bounds the wait for the file lock, held by another running instance
+const storeOpenTimeout = time.Second
+
+// storePath returns the location of the local database, next to prefs.json
+func storePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+	return filepath.Join(dir, "goCheckAmi", "goCheckAmi.db"), nil
+}
+
+// historyPath returns the location of the SQLite scan history, next to the local database
+func historyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+	return filepath.Join(dir, "goCheckAmi", "history.sqlite"), nil
+}
+
+// db opens the local database on first use and creates the buckets
+func (a *App) db() (*bolt.DB, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.store != nil {
+		return a.store, nil
+	}
+
+	path, err := storePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database dir: %w", err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: storeOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range storeBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create database buckets: %w", err)
+	}
+	a.store = db
+	return db, nil
+}
+
+// storeBuckets are the buckets of the local database
+var storeBuckets = [][]byte{resultsBucket, presetsBucket, favoritesBucket}
+
+// historyDB opens the scan history on first use and creates its tables. A single
+// connection serializes the writes of the scheduler and of the GUI; a scan recorded
+// by another running instance is waited for up to storeOpenTimeout.
+func (a *App) historyDB() (*sql.DB, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.history != nil {
+		return a.history, nil
+	}
+
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database dir: %w", err)
+	}
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(%d)", path, storeOpenTimeout.Milliseconds())
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(historySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history tables: %w", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to restrict history permissions: %w", err)
+	}
+	a.history = db
+	return db, nil
+}
+
+// closeStore closes the local database and the scan history, if they were opened
+func (a *App) closeStore() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.store != nil {
+		a.store.Close()
+		a.store = nil
+	}
+	if a.history != nil {
+		a.history.Close()
+		a.history = nil
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"golang.org/x/sync/errgroup"
+)
+
+// AWSSummary holds totals for the dashboard without the full result rows
+type AWSSummary struct {
+	ParameterCount   int            `json:"parameterCount"`
+	InstanceCount    int            `json:"instanceCount"`
+	InstancesByState map[string]int `json:"instancesByState"`
+}
+
+// Summary counts the parameters matching filter and the instances per state.
+// Only counters are kept while paginating, so huge accounts stay cheap.
+func (a *App) Summary(profile, filter string) (*AWSSummary, error) {
+	cfg, err := a.authenticate(a.ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := buildParameterFilters(SSMFilter{NamePrefix: filter})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AWSSummary{InstancesByState: make(map[string]int)}
+	g, ctx := errgroup.WithContext(a.ctx)
+
+	// SSM and EC2 are counted concurrently; each goroutine owns its own counters
+	g.Go(func() error {
+		paginator := ssm.NewDescribeParametersPaginator(a.clientBackend().SSM(cfg), &ssm.DescribeParametersInput{
+			ParameterFilters: filters,
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list params: %w", err)
+			}
+			summary.ParameterCount += len(page.Parameters)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		ec2Pager := ec2.NewDescribeInstancesPaginator(a.clientBackend().EC2(cfg), &ec2.DescribeInstancesInput{})
+		for ec2Pager.HasMorePages() {
+			page, err := ec2Pager.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to describe instances: %w", err)
+			}
+			for _, res := range page.Reservations {
+				for _, inst := range res.Instances {
+					summary.InstanceCount++
+					state := "unknown"
+					if inst.State != nil && inst.State.Name != "" {
+						state = string(inst.State.Name)
+					}
+					summary.InstancesByState[state]++
+				}
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
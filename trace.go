@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// maxTracedCalls bounds the API trace; the oldest calls are dropped first
+const maxTracedCalls = 2000
+
+// APICall is an AWS API call recorded while tracing is on
+type APICall struct {
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	Region    string `json:"region"`
+	// StartedAt is RFC 3339 with milliseconds; DurationMs covers every attempt
+	StartedAt  string `json:"startedAt"`
+	DurationMs int64  `json:"durationMs"`
+	// Retries is the number of attempts after the first one
+	Retries    int    `json:"retries"`
+	StatusCode int    `json:"statusCode"`
+	RequestID  string `json:"requestId"`
+	// ErrorCode is the AWS error code of a failed call, e.g. ThrottlingException
+	ErrorCode string `json:"errorCode,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// apiTrace is the ring buffer of the traced calls
+type apiTrace struct {
+	mu      sync.Mutex
+	enabled bool
+	calls   []APICall
+}
+
+func (t *apiTrace) add(call APICall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.calls) >= maxTracedCalls {
+		t.calls = t.calls[1:]
+	}
+	t.calls = append(t.calls, call)
+}
+
+func (t *apiTrace) isEnabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.enabled
+}
+
+// SetAPITracing turns the recording of the AWS API calls on or off. Turning it
+// on clears the previous trace.
+func (a *App) SetAPITracing(enabled bool) {
+	a.trace.mu.Lock()
+	defer a.trace.mu.Unlock()
+	if enabled && !a.trace.enabled {
+		a.trace.calls = nil
+	}
+	a.trace.enabled = enabled
+}
+
+// GetAPITrace returns the calls recorded since tracing was turned on, oldest first
+func (a *App) GetAPITrace() []APICall {
+	a.trace.mu.Lock()
+	defer a.trace.mu.Unlock()
+	calls := make([]APICall, len(a.trace.calls))
+	copy(calls, a.trace.calls)
+	return calls
+}
+
+// ClearAPITrace drops the recorded calls
+func (a *App) ClearAPITrace() {
+	a.trace.mu.Lock()
+	defer a.trace.mu.Unlock()
+	a.trace.calls = nil
+}
+
+// traceLoadOption adds the tracing middleware to every client created from the
+// config. It is always installed so cached configs trace as soon as tracing is on.
+func (a *App) traceLoadOption() config.LoadOptionsFunc {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("APITrace", a.traceCall), middleware.After)
+		},
+	})
+}
+
+// traceCall records a call, with every retry, and emits it as an api:call event
+func (a *App) traceCall(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	if !a.trace.isEnabled() {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	call := APICall{
+		Service:    awsmiddleware.GetServiceID(ctx),
+		Operation:  awsmiddleware.GetOperationName(ctx),
+		Region:     awsmiddleware.GetRegion(ctx),
+		StartedAt:  start.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if attempts, ok := retry.GetAttemptResults(metadata); ok && len(attempts.Results) > 1 {
+		call.Retries = len(attempts.Results) - 1
+	}
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+		call.StatusCode = resp.StatusCode
+	}
+	call.RequestID, _ = awsmiddleware.GetRequestIDMetadata(metadata)
+	if err != nil {
+		call.Error = err.Error()
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			call.ErrorCode = apiErr.ErrorCode()
+		}
+	}
+
+	a.trace.add(call)
+	a.emit("api:call", call)
+	slog.Debug("AWS API call", "service", call.Service, "operation", call.Operation, "region", call.Region,
+		"durationMs", call.DurationMs, "retries", call.Retries, "status", call.StatusCode,
+		"requestId", call.RequestID, "errorCode", call.ErrorCode)
+	return out, metadata, err
+}
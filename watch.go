@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// minWatchInterval keeps watch mode from hammering the APIs
+const minWatchInterval = 30 * time.Second
+
+// WatchDelta is emitted as "watch:delta" when a poll of a watched profile differs from the previous one
+type WatchDelta struct {
+	Profile    string                   `json:"profile"`
+	ScannedAt  string                   `json:"scannedAt"`
+	Added      []EC2Instance            `json:"added"`
+	Removed    []EC2Instance            `json:"removed"`
+	Changed    []InstanceChange         `json:"changed"`
+	Parameters []ParameterVersionChange `json:"parameters"`
+}
+
+// WatchError is emitted as "watch:error" when a poll fails; the watch goes on
+type WatchError struct {
+	Profile string `json:"profile"`
+	Error   string `json:"error"`
+}
+
+// StartWatch polls the profile's default region every intervalSeconds (at least 30)
+// and emits the changes since the previous poll as watch:delta events. The first
+// poll is the baseline. Starting a watch again replaces the previous one.
+func (a *App) StartWatch(profile string, intervalSeconds int) error {
+	if profile == "" {
+		return fmt.Errorf("profile is required")
+	}
+	interval := time.Duration(intervalSeconds) * time.Second
+	if interval < minWatchInterval {
+		return fmt.Errorf("watch interval must be at least %s, got %s", minWatchInterval, interval)
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.mu.Lock()
+	if stop, ok := a.watches[profile]; ok {
+		stop()
+	}
+	a.watches[profile] = cancel
+	a.mu.Unlock()
+
+	go a.watch(ctx, profile, interval)
+	return nil
+}
+
+// StopWatch stops watching the profile
+func (a *App) StopWatch(profile string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stop, ok := a.watches[profile]
+	if !ok {
+		return fmt.Errorf("profile %q is not watched", profile)
+	}
+	stop()
+	delete(a.watches, profile)
+	return nil
+}
+
+// WatchedProfiles lists the profiles being watched
+func (a *App) WatchedProfiles() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	profiles := make([]string, 0, len(a.watches))
+	for p := range a.watches {
+		profiles = append(profiles, p)
+	}
+	return profiles
+}
+
+// stopAllWatches cancels every watch, on shutdown
+func (a *App) stopAllWatches() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for p, stop := range a.watches {
+		stop()
+		delete(a.watches, p)
+	}
+}
+
+func (a *App) watch(ctx context.Context, profile string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *AWSResult
+	for {
+		result, err := a.watchScan(ctx, profile)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			a.emit("watch:error", WatchError{Profile: profile, Error: err.Error()})
+		default:
+			if last != nil {
+				if delta := newWatchDelta(profile, last, result); !delta.empty() {
+					a.emit("watch:delta", delta)
+				}
+			}
+			last = result
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchScan scans the profile without going through the result cache or the history,
+// which the polls would otherwise flood
+func (a *App) watchScan(ctx context.Context, profile string) (*AWSResult, error) {
+	cfg, err := a.authenticate(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.scanAccount(ctx, cfg, ProcessingRequest{Profile: profile})
+	if err != nil {
+		return nil, err
+	}
+	result.ScannedAt = time.Now().UTC().Format(time.RFC3339)
+	return result, nil
+}
+
+func newWatchDelta(profile string, before, after *AWSResult) WatchDelta {
+	diff := diffResults(before, after)
+	return WatchDelta{
+		Profile:    profile,
+		ScannedAt:  after.ScannedAt,
+		Added:      diff.Added,
+		Removed:    diff.Removed,
+		Changed:    diff.Changed,
+		Parameters: parameterChanges(before, after),
+	}
+}
+
+func (d WatchDelta) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && len(d.Parameters) == 0
+}
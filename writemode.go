@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrWriteModeDisabled is returned by the methods that change AWS resources
+// until the user opts in with SetWriteMode
+var ErrWriteModeDisabled = errors.New("write mode is disabled, enable it to change AWS resources")
+
+// confirmationTTL bounds how long a confirmation token stays valid
+const confirmationTTL = 5 * time.Minute
+
+// pendingConfirmation is an action the user was asked to confirm
+type pendingConfirmation struct {
+	action  string
+	target  string
+	expires time.Time
+}
+
+// SetWriteMode allows or forbids the methods that change AWS resources.
+// It is off at startup, so the app stays read-only unless the user opts in.
+func (a *App) SetWriteMode(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.writeMode = enabled
+}
+
+// WriteModeEnabled reports whether SetWriteMode was turned on
+func (a *App) WriteModeEnabled() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.writeMode
+}
+
+// issueConfirmation returns a single-use token for running action on target
+func (a *App) issueConfirmation(action, target string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.confirmations[token] = pendingConfirmation{action: action, target: target, expires: time.Now().Add(confirmationTTL)}
+	return token, nil
+}
+
+// checkWriteAccess makes sure write mode is on and consumes the confirmation
+// token issued for the same action and target
+func (a *App) checkWriteAccess(token, action, target string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.writeMode {
+		return ErrWriteModeDisabled
+	}
+	pending, ok := a.confirmations[token]
+	if !ok {
+		return fmt.Errorf("unknown confirmation token for %s %s", action, target)
+	}
+	delete(a.confirmations, token)
+	if pending.action != action || pending.target != target {
+		return fmt.Errorf("confirmation token was issued for %s %s, not %s %s", pending.action, pending.target, action, target)
+	}
+	if time.Now().After(pending.expires) {
+		return fmt.Errorf("confirmation token for %s %s expired", action, target)
+	}
+	return nil
+}
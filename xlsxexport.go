@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"github.com/xuri/excelize/v2"
+)
+
+// staleFillColor highlights the rows of stale AMIs
+const staleFillColor = "FFEB9C"
+
+// amiColumns are the columns of the AMIs sheet, one row per AMI in use
+var amiColumns = []reportColumn[AMIGroup]{
+	{"ami", func(g AMIGroup) string { return g.AMI }},
+	{"amiName", func(g AMIGroup) string { return g.Instances[0].AMIName }},
+	{"amiCreationDate", func(g AMIGroup) string { return g.Instances[0].AMICreationDate }},
+	{"amiAgeDays", func(g AMIGroup) string { return strconv.Itoa(g.Instances[0].AMIAgeDays) }},
+	{"amiStale", func(g AMIGroup) string { return strconv.FormatBool(g.Instances[0].AMIStale) }},
+	{"amiStatus", func(g AMIGroup) string { return g.Instances[0].AMIStatus }},
+	{"deprecated", func(g AMIGroup) string { return strconv.FormatBool(g.Deprecated) }},
+	{"instances", func(g AMIGroup) string { return strconv.Itoa(g.Count) }},
+	{"regions", func(g AMIGroup) string { return countsList(g.Regions) }},
+}
+
+// ExportResultXLSX asks where to save and writes the result with ExportXLSX.
+// It returns the path written, or "" if the user cancelled the dialog.
+func (a *App) ExportResultXLSX(result AWSResult) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export scan report",
+		DefaultFilename: "scan.xlsx",
+		Filters:         []runtime.FileFilter{{DisplayName: "Excel workbook", Pattern: "*.xlsx"}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	return path, a.ExportXLSX(result, path)
+}
+
+// ExportXLSX writes the result as a workbook with Instances, AMIs and Parameters
+// sheets. Headers are auto-filtered and the rows of stale AMIs are highlighted.
+func (a *App) ExportXLSX(result AWSResult, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	header, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+	stale, err := f.NewConditionalStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{staleFillColor}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create stale style: %w", err)
+	}
+
+	sheets := []struct {
+		name   string
+		header []string
+		rows   [][]string
+		// staleColumn is the column holding amiStale, -1 for none
+		staleColumn int
+	}{
+		{"Instances", columnNames(instanceColumns), columnRows(instanceColumns, result.Instances), columnIndex(instanceColumns, "amiStale")},
+		{"AMIs", columnNames(amiColumns), columnRows(amiColumns, knownAMIGroups(&result)), columnIndex(amiColumns, "amiStale")},
+		{"Parameters", columnNames(parameterColumns), columnRows(parameterColumns, result.Parameters), -1},
+	}
+
+	for i, s := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", s.name); err != nil {
+				return fmt.Errorf("failed to name sheet %s: %w", s.name, err)
+			}
+		} else if _, err := f.NewSheet(s.name); err != nil {
+			return fmt.Errorf("failed to create sheet %s: %w", s.name, err)
+		}
+
+		if err := f.SetSheetRow(s.name, "A1", &s.header); err != nil {
+			return fmt.Errorf("failed to write %s header: %w", s.name, err)
+		}
+		if err := f.SetRowStyle(s.name, 1, 1, header); err != nil {
+			return fmt.Errorf("failed to style %s header: %w", s.name, err)
+		}
+		for r, row := range s.rows {
+			cell, _ := excelize.CoordinatesToCellName(1, r+2)
+			if err := f.SetSheetRow(s.name, cell, &row); err != nil {
+				return fmt.Errorf("failed to write %s row: %w", s.name, err)
+			}
+		}
+
+		lastCol, _ := excelize.ColumnNumberToName(len(s.header))
+		lastRow := max(len(s.rows)+1, 2)
+		if err := f.AutoFilter(s.name, fmt.Sprintf("A1:%s%d", lastCol, lastRow), nil); err != nil {
+			return fmt.Errorf("failed to filter %s: %w", s.name, err)
+		}
+		if s.staleColumn >= 0 && len(s.rows) > 0 {
+			col, _ := excelize.ColumnNumberToName(s.staleColumn + 1)
+			err := f.SetConditionalFormat(s.name, fmt.Sprintf("A2:%s%d", lastCol, lastRow), []excelize.ConditionalFormatOptions{
+				{Type: "formula", Criteria: fmt.Sprintf(`$%s2="true"`, col), Format: &stale},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to highlight stale rows of %s: %w", s.name, err)
+			}
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// knownAMIGroups groups the instances by AMI, leaving out those with an unknown AMI
+func knownAMIGroups(result *AWSResult) []AMIGroup {
+	var groups []AMIGroup
+	for _, g := range GroupInstancesByAMI(result) {
+		if g.AMI != unknownAMI {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// columnIndex returns the position of the named column, -1 if absent
+func columnIndex[T any](columns []reportColumn[T], name string) int {
+	for i, c := range columns {
+		if c.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// countsList renders a count per key as "a (2) b (1)", sorted by key
+func countsList(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s (%d)", k, counts[k])
+	}
+	return strings.Join(parts, " ")
+}